@@ -0,0 +1,257 @@
+package venom
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/jimschubert/venom/internal"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrDocumentationOutdated is returned by the documentation command in check mode when the contents of the output
+// directory differ from the documentation which would be generated
+var ErrDocumentationOutdated = errors.New("documentation is out of date")
+
+// generationDateSentinel replaces the generation date while checking, so the date can be pinned to that of existing files
+const generationDateSentinel = "VENOMGENERATIONDATE"
+
+// dateContextWidth is the maximum length of text on either side of the generation date used to locate it in existing files
+const dateContextWidth = 32
+
+// CheckResult describes how the contents of the output directory differ from the documentation which would be generated.
+// All file names are slash-separated and relative to the output directory.
+type CheckResult struct {
+	// Added are files which would be generated, but do not exist
+	Added []string
+	// Changed are files whose contents differ from what would be generated
+	Changed []string
+	// Removed are existing files which would no longer be generated
+	Removed []string
+	// Diffs are unified diffs of each added, changed, or removed file, keyed by file name
+	Diffs map[string]string
+}
+
+// IsCurrent determines if the output directory matches the documentation which would be generated
+func (c *CheckResult) IsCurrent() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// String provides a summary of all added, changed, and removed files followed by their diffs
+func (c *CheckResult) String() string {
+	if c.IsCurrent() {
+		return "Documentation is up to date.\n"
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString("Documentation is out of date:\n")
+	names := make([]string, 0)
+	for _, group := range []struct {
+		label string
+		files []string
+	}{{"added", c.Added}, {"changed", c.Changed}, {"removed", c.Removed}} {
+		for _, name := range group.files {
+			buf.WriteString(fmt.Sprintf("  %-8s %s\n", group.label+":", name))
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		if diff := c.Diffs[name]; diff != "" {
+			buf.WriteString("\n")
+			buf.WriteString(diff)
+		}
+	}
+	return buf.String()
+}
+
+// Check renders all enabled formats in memory and compares the result with the contents of the output directory.
+// Unless pinned by WithGenerationDate or SOURCE_DATE_EPOCH, the generation date is that found in existing files, so
+// documentation is only reported as changed when its content differs.
+func Check(documentation Documentation) (*CheckResult, error) {
+	documentation.init()
+	generationDate := documentation.GenerationDate
+
+	// writers log each file as written, which is misleading when nothing is written
+	options := *documentation.options
+	templateOptions := options.TemplateOptions()
+	templateOptions.Logger = discardLogger{}
+	options.templateOptions = &templateOptions
+
+	documentation.options = &options
+	if !options.hasGenerationDate() {
+		documentation.GenerationDate = generationDateSentinel
+	}
+
+	rendered, err := Render(documentation)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CheckResult{Diffs: make(map[string]string)}
+	roots := make(map[string]bool)
+	extensions := make(map[string]bool)
+
+	for name, data := range rendered {
+		roots[strings.SplitN(name, "/", 2)[0]] = true
+		extensions[path.Ext(strings.TrimSuffix(name, ".gz"))] = true
+
+		want := decompressed(name, data)
+		existing, err := os.ReadFile(filepath.Join(options.outDir, filepath.FromSlash(name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			want = pinGenerationDate(want, nil, generationDate)
+			result.Added = append(result.Added, name)
+			result.Diffs[name] = internal.UnifiedDiff("/dev/null", path.Join("b", name), "", string(want), 3)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		have := decompressed(name, existing)
+		want = pinGenerationDate(want, have, generationDate)
+		if !bytes.Equal(want, have) {
+			result.Changed = append(result.Changed, name)
+			result.Diffs[name] = internal.UnifiedDiff(path.Join("a", name), path.Join("b", name), string(have), string(want), 3)
+		}
+	}
+
+	previous, hasManifest, err := loadManifest(options.outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasManifest {
+		// only files known to be generated for an enabled format can be stale
+		for name, entry := range previous.Files {
+			if _, ok := rendered[name]; ok || !options.hasFormat(entry.Format) {
+				continue
+			}
+			existing, err := os.ReadFile(filepath.Join(options.outDir, filepath.FromSlash(name)))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			result.Removed = append(result.Removed, name)
+			result.Diffs[name] = internal.UnifiedDiff(path.Join("a", name), "/dev/null", string(decompressed(name, existing)), "", 3)
+		}
+		roots = nil
+	}
+
+	// without a manifest, any file in a documentation directory with the extension of a generated file may be stale
+	for root := range roots {
+		err := filepath.WalkDir(filepath.Join(options.outDir, root), func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(options.outDir, p)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+			if _, ok := rendered[name]; ok || !extensions[path.Ext(strings.TrimSuffix(name, ".gz"))] {
+				return nil
+			}
+
+			existing, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			result.Removed = append(result.Removed, name)
+			result.Diffs[name] = internal.UnifiedDiff(path.Join("a", name), "/dev/null", string(decompressed(name, existing)), "", 3)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Changed)
+	sort.Strings(result.Removed)
+	return result, nil
+}
+
+// decompressed provides the uncompressed contents of gzip files, so differences can be reported as text
+func decompressed(name string, data []byte) []byte {
+	if !strings.HasSuffix(name, ".gz") {
+		return data
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return data
+	}
+	result, err := io.ReadAll(gz)
+	if err != nil {
+		return data
+	}
+	return result
+}
+
+// pinGenerationDate replaces the generation date sentinel in rendered with the date found at the same location in
+// existing, falling back to fallback when existing has no such date
+func pinGenerationDate(rendered []byte, existing []byte, fallback string) []byte {
+	idx := bytes.Index(rendered, []byte(generationDateSentinel))
+	if idx < 0 {
+		return rendered
+	}
+
+	date := fallback
+	if existing != nil {
+		lineStart := bytes.LastIndexByte(rendered[:idx], '\n') + 1
+		after := idx + len(generationDateSentinel)
+		lineEnd := bytes.IndexByte(rendered[after:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(rendered)
+		} else {
+			lineEnd += after
+		}
+
+		pattern := strings.Builder{}
+		prefixStart := maxInt(lineStart, idx-dateContextWidth)
+		if prefixStart == lineStart {
+			pattern.WriteString("(?m:^)")
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(rendered[prefixStart:idx])))
+		pattern.WriteString(`([^\n]*?)`)
+
+		suffix := rendered[after:lineEnd]
+		if next := bytes.Index(suffix, []byte(generationDateSentinel)); next >= 0 {
+			suffix = suffix[:next]
+		}
+		if len(suffix) > dateContextWidth {
+			suffix = suffix[:dateContextWidth]
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(suffix)))
+		if after+len(suffix) == lineEnd {
+			pattern.WriteString("(?m:$)")
+		}
+
+		if matches := regexp.MustCompile(pattern.String()).FindSubmatch(existing); matches != nil {
+			date = string(matches[1])
+		}
+	}
+
+	return bytes.ReplaceAll(rendered, []byte(generationDateSentinel), []byte(date))
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Printf(string, ...any) {}
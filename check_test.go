@@ -0,0 +1,149 @@
+package venom
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-test/deep"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCheckTestCommand(children ...string) *cobra.Command {
+	root := &cobra.Command{Use: "simple", Short: "s"}
+	for _, child := range children {
+		root.AddCommand(&cobra.Command{Use: child, Short: child, Run: func(cmd *cobra.Command, args []string) {}})
+	}
+	return root
+}
+
+func TestCheck(t *testing.T) {
+	// the generation date is only taken from existing files when it isn't pinned
+	t.Setenv(sourceDateEpochEnv, "")
+	outDir := t.TempDir()
+	opts := NewOptions().
+		WithFormats(Markdown | Man | Json).
+		WithGzipManPages().
+		WithOutDirectory(outDir).
+		WithLogger(log.New(io.Discard, "", 0))
+
+	written := NewDocumentation(newCheckTestCommand("first", "second"), opts)
+	written.GenerationDate = "1-Jan-2023"
+	if err := Write(written); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	t.Run("is current regardless of generation date", func(t *testing.T) {
+		doc := NewDocumentation(newCheckTestCommand("first", "second"), opts)
+		doc.GenerationDate = "2-Feb-2024"
+		result, err := Check(doc)
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if !result.IsCurrent() {
+			t.Errorf("Check() expected current documentation, got:\n%s", result)
+		}
+	})
+
+	t.Run("reports generation date differing from a pinned date", func(t *testing.T) {
+		pinned := *opts
+		doc := NewDocumentation(newCheckTestCommand("first", "second"), pinned.WithGenerationDate(time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC)))
+		result, err := Check(doc)
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if !contains(result.Changed, "simple/simple.md") || !strings.Contains(result.Diffs["simple/simple.md"], "+###### Auto-generated by jimschubert/venom 2-Feb-2024") {
+			t.Errorf("Check() expected pinned generation date to be reported, got:\n%s", result)
+		}
+	})
+
+	t.Run("reports generation date differing from SOURCE_DATE_EPOCH", func(t *testing.T) {
+		t.Setenv(sourceDateEpochEnv, "1706832000")
+		result, err := Check(NewDocumentation(newCheckTestCommand("first", "second"), opts))
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if !contains(result.Changed, "simple/simple.md") {
+			t.Errorf("Check() expected generation date of SOURCE_DATE_EPOCH to be reported, got:\n%s", result)
+		}
+	})
+
+	t.Run("reports added, changed, and removed files", func(t *testing.T) {
+		root := newCheckTestCommand("first", "third")
+		root.Long = "now with a long description"
+		doc := NewDocumentation(root, opts)
+		result, err := Check(doc)
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+
+		if diff := deep.Equal(result.Added, []string{"simple/simple-third.1.gz", "simple/simple_third.md"}); diff != nil {
+			t.Errorf("Check() added: %v", diff)
+		}
+		if diff := deep.Equal(result.Changed, []string{"simple/index.md", "simple/simple.1.gz", "simple/simple.json", "simple/simple.md"}); diff != nil {
+			t.Errorf("Check() changed: %v", diff)
+		}
+		if diff := deep.Equal(result.Removed, []string{"simple/simple-second.1.gz", "simple/simple_second.md"}); diff != nil {
+			t.Errorf("Check() removed: %v", diff)
+		}
+
+		wantDiff := "--- a/simple/simple.md\n+++ b/simple/simple.md\n@@ -1,8 +1,13 @@\n ## simple\n+\n+### Synopsis\n+now with a long description\n+\n+\n ## SEE ALSO\n \n" +
+			" * [simple first](./simple_first.md) - first\n-* [simple second](./simple_second.md) - second\n+* [simple third](./simple_third.md) - third\n \n \n" +
+			" ###### Auto-generated by jimschubert/venom 1-Jan-2023\n"
+		if got := result.Diffs["simple/simple.md"]; got != wantDiff {
+			t.Errorf("Check() diff = %q, want %q", got, wantDiff)
+		}
+		if strings.Contains(result.String(), generationDateSentinel) || !strings.Contains(result.String(), "1-Jan-2023") {
+			t.Errorf("Check() expected generation date to be pinned:\n%s", result)
+		}
+	})
+
+	t.Run("ignores files not generated by enabled formats", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(outDir, "simple", "notes.txt"), []byte("notes"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		result, err := Check(NewDocumentation(newCheckTestCommand("first", "second"), opts))
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if !result.IsCurrent() {
+			t.Errorf("Check() expected current documentation, got:\n%s", result)
+		}
+	})
+
+	t.Run("docs command fails in check mode", func(t *testing.T) {
+		root := newCheckTestCommand("first", "third")
+		if err := Initialize(root, opts); err != nil {
+			t.Fatalf("Initialize() error = %v", err)
+		}
+		stderr := bytes.Buffer{}
+		root.SetErr(&stderr)
+		root.SetOut(io.Discard)
+		root.SetArgs([]string{"docs", "--check"})
+		if err := root.Execute(); !errors.Is(err, ErrDocumentationOutdated) {
+			t.Fatalf("Execute() error = %v, want %v", err, ErrDocumentationOutdated)
+		}
+		if !strings.Contains(stderr.String(), "added:   simple/simple_third.md") {
+			t.Errorf("Execute() expected report of added files, got:\n%s", stderr.String())
+		}
+	})
+	t.Run("docs command has no check flag with fixed options", func(t *testing.T) {
+		fixed := *opts
+		root := newCheckTestCommand("first")
+		if err := Initialize(root, fixed.DisableUserCommandOptions()); err != nil {
+			t.Fatalf("Initialize() error = %v", err)
+		}
+		docs, _, err := root.Find([]string{"docs"})
+		if err != nil || docs.Name() != "docs" {
+			t.Fatalf("Find() = %v, error = %v", docs.Name(), err)
+		}
+		if docs.Flags().Lookup("check") != nil {
+			t.Errorf("Initialize() unexpectedly defined --check")
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package venom
 
-// Formats defines the flag of supported documentation formats
+import "strings"
+
+// Formats defines the flag of the original built-in documentation formats. The set of flags is frozen: each flag is an
+// alias for the registered format of the same (lower-cased) name, and all other formats are selected by name using
+// Options.WithFormatNames.
 type Formats byte
 
 const (
@@ -35,15 +39,28 @@ func (f *Formats) Unset(format Formats) *Formats {
 
 // IsValid determines if this set of Formats flags are valid; anything set but not defined in the Formats flag set will return false.
 func (f *Formats) IsValid() bool {
-	return f.IsSet(Markdown) || f.IsSet(Man) || f.IsSet(Yaml) || f.IsSet(ReST) || f.IsSet(Json)
+	return len(f.defined()) > 0
 }
 
+// builtinFormats are the frozen Formats flags, in the order they're defined and written
+var builtinFormats = []Formats{Yaml, Json, Markdown, Man, ReST}
+
+// defined provides the flags set in f which alias a registered format
 func (f *Formats) defined() []Formats {
 	defined := make([]Formats, 0)
-	for _, format := range []Formats{Yaml, Json, Markdown, Man, ReST} {
-		if f.IsSet(format) {
+	for _, format := range builtinFormats {
+		if _, ok := LookupFormat(format.String()); ok && f.IsSet(format) {
 			defined = append(defined, format)
 		}
 	}
 	return defined
 }
+
+// names provides the registered format names for each defined flag
+func (f Formats) names() []string {
+	names := make([]string, 0)
+	for _, format := range f.defined() {
+		names = append(names, strings.ToLower(format.String()))
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/venom/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatterFn provides front matter for the page of a command. Returned values are merged over venom's defaults, and a
+// nil value removes a default.
+type FrontMatterFn func(c Command) map[string]interface{}
+
+// FrontMatterFormat defines the serialization of front matter
+type FrontMatterFormat string
+
+const (
+	// FrontMatterYaml serializes front matter as YAML, between --- delimiters
+	FrontMatterYaml FrontMatterFormat = "yaml"
+	// FrontMatterToml serializes front matter as TOML, between +++ delimiters
+	FrontMatterToml FrontMatterFormat = "toml"
+)
+
+// IsValid determines if this is a supported FrontMatterFormat
+func (f FrontMatterFormat) IsValid() bool {
+	return f == FrontMatterYaml || f == FrontMatterToml
+}
+
+// defaultFrontMatter provides the title, description, weight, and aliases of c, where weight is the position of c among
+// its visible siblings
+func defaultFrontMatter(root Command, c Command) map[string]interface{} {
+	values := map[string]interface{}{
+		"title":  c.FullPath,
+		"weight": commandPosition(root, c),
+	}
+	if c.Short != "" {
+		values["description"] = c.Short
+	}
+	if len(c.Aliases) > 0 {
+		values["aliases"] = c.Aliases
+	}
+	return values
+}
+
+// frontMatter merges the values provided by fn over the defaults for c, and serializes them in the given format
+func frontMatter(format FrontMatterFormat, fn FrontMatterFn, root Command, c Command) (string, error) {
+	values := defaultFrontMatter(root, c)
+	if fn != nil {
+		for key, value := range fn(c) {
+			if value == nil {
+				delete(values, key)
+			} else {
+				values[key] = value
+			}
+		}
+	}
+
+	switch format {
+	case FrontMatterToml:
+		b, err := internal.MarshalToml(values)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("+++\n%s+++\n\n", b), nil
+	default:
+		b, err := yaml.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("---\n%s---\n\n", b), nil
+	}
+}
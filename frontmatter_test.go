@@ -0,0 +1,78 @@
+package venom
+
+import (
+	"testing"
+)
+
+func Test_frontMatter(t *testing.T) {
+	root := Command{
+		Name:     "simple",
+		FullPath: "simple",
+		Short:    "s",
+		Subcommands: []Command{
+			{Name: "hidden", FullPath: "simple hidden", Hidden: true, Parent: &ParentCommand{FullPath: "simple"}},
+			{Name: "first", FullPath: "simple first", Parent: &ParentCommand{FullPath: "simple"}},
+			{Name: "second", FullPath: "simple second", Short: "2", Aliases: []string{"two", "2nd"}, Parent: &ParentCommand{FullPath: "simple"}},
+		},
+	}
+
+	type args struct {
+		format FrontMatterFormat
+		fn     FrontMatterFn
+		c      Command
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "yaml defaults for root",
+			args: args{format: FrontMatterYaml, c: root},
+			want: "---\ndescription: s\ntitle: simple\nweight: 1\n---\n\n",
+		},
+		{
+			name: "yaml defaults weighted among visible siblings",
+			args: args{format: FrontMatterYaml, c: root.Subcommands[2]},
+			want: "---\naliases:\n    - two\n    - 2nd\ndescription: \"2\"\ntitle: simple second\nweight: 2\n---\n\n",
+		},
+		{
+			name: "toml merges over defaults",
+			args: args{
+				format: FrontMatterToml,
+				fn: func(c Command) map[string]interface{} {
+					return map[string]interface{}{
+						"title":  c.Name,
+						"weight": nil,
+						"menu":   map[string]interface{}{"main": map[string]interface{}{"parent": "cli"}},
+					}
+				},
+				c: root.Subcommands[1],
+			},
+			want: "+++\ntitle = \"first\"\n\n[menu]\n\n[menu.main]\nparent = \"cli\"\n+++\n\n",
+		},
+		{
+			name: "unsupported toml values",
+			args: args{
+				format: FrontMatterToml,
+				fn: func(c Command) map[string]interface{} {
+					return map[string]interface{}{"invalid": struct{}{}}
+				},
+				c: root,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := frontMatter(tt.args.format, tt.args.fn, root, tt.args.c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("frontMatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("frontMatter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
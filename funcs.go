@@ -1,6 +1,9 @@
 package venom
 
-import "text/template"
+import (
+	"strings"
+	"text/template"
+)
 
 // functions defines the common set of functions for template providers
 type functions interface {
@@ -14,8 +17,14 @@ type functions interface {
 	IsLocalFlag(input Flag) bool
 }
 
+// extendedFunctions may be implemented by template providers which require format-specific functions in addition to
+// the common set. Common functions take precedence over any extended function of the same name.
+type extendedFunctions interface {
+	Funcs() template.FuncMap
+}
+
 func newFuncMap(fns functions) template.FuncMap {
-	return template.FuncMap{
+	funcMap := template.FuncMap{
 		"header":        fns.FormatHeader,
 		"text":          fns.FormatText,
 		"options":       fns.FormatOptions,
@@ -24,6 +33,7 @@ func newFuncMap(fns functions) template.FuncMap {
 		"example":       fns.FormatExample,
 		"autogen":       fns.FormatAutoGenTag,
 		"is_local":      fns.IsLocalFlag,
+		"join":          strings.Join,
 		"seq": func(value int) []int {
 			var res []int
 			for i := 0; i < value; i++ {
@@ -32,4 +42,13 @@ func newFuncMap(fns functions) template.FuncMap {
 			return res
 		},
 	}
+
+	if extended, ok := fns.(extendedFunctions); ok {
+		for name, fn := range extended.Funcs() {
+			if _, exists := funcMap[name]; !exists {
+				funcMap[name] = fn
+			}
+		}
+	}
+	return funcMap
 }
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the memory used to compute the longest common subsequence of differing lines. Beyond this, the
+// differing region is reported as a single replacement.
+const maxDiffCells = 4 * 1024 * 1024
+
+type diffOp struct {
+	kind byte // one of ' ', '-', '+'
+	line string
+}
+
+// UnifiedDiff produces a unified diff between the lines of from and to, with context lines surrounding each change.
+// An empty string is returned when the inputs are equal.
+func UnifiedDiff(fromName, toName, from, to string, context int) string {
+	if from == to {
+		return ""
+	}
+
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	buf := strings.Builder{}
+	buf.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName))
+
+	for start := 0; start < len(ops); {
+		// find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		hunkStart := start - context
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+
+		// extend the hunk until a run of unchanged lines is long enough to separate it from the next change
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = minInt(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+
+		fromLine, toLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.kind != '+' {
+				fromLine++
+			}
+			if op.kind != '-' {
+				toLine++
+			}
+		}
+
+		fromCount, toCount := 0, 0
+		for _, op := range ops[hunkStart:end] {
+			if op.kind != '+' {
+				fromCount++
+			}
+			if op.kind != '-' {
+				toCount++
+			}
+		}
+
+		buf.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount)))
+		for _, op := range ops[hunkStart:end] {
+			buf.WriteByte(op.kind)
+			buf.WriteString(op.line)
+			buf.WriteByte('\n')
+		}
+
+		start = end
+	}
+
+	return buf.String()
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		// an empty range refers to the line before the change
+		return fmt.Sprintf("%d,0", line-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+func splitLines(input string) []string {
+	if input == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+}
+
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	middleA := a[prefix : len(a)-suffix]
+	middleB := b[prefix : len(b)-suffix]
+	if (len(middleA)+1)*(len(middleB)+1) > maxDiffCells {
+		for _, line := range middleA {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range middleB {
+			ops = append(ops, diffOp{'+', line})
+		}
+	} else {
+		ops = append(ops, lcsDiff(middleA, middleB)...)
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// lcsDiff computes the edit script between a and b using the longest common subsequence of lines
+func lcsDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	// lengths[i][j] is the length of the LCS of a[i:] and b[j:]
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
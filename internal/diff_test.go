@@ -0,0 +1,48 @@
+package internal
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	type args struct {
+		from string
+		to   string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "no diff for equal input",
+			args: args{from: "a\nb\n", to: "a\nb\n"},
+			want: "",
+		},
+		{
+			name: "single changed line with context",
+			args: args{from: "1\n2\n3\n4\n5\n6\n7\n", to: "1\n2\n3\nfour\n5\n6\n7\n"},
+			want: "--- old\n+++ new\n@@ -2,5 +2,5 @@\n 2\n 3\n-4\n+four\n 5\n 6\n",
+		},
+		{
+			name: "added file",
+			args: args{from: "", to: "a\nb\n"},
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "removed line at end",
+			args: args{from: "a\nb\nc\n", to: "a\nb\n"},
+			want: "--- old\n+++ new\n@@ -1,3 +1,2 @@\n a\n b\n-c\n",
+		},
+		{
+			name: "separate hunks for distant changes",
+			args: args{from: "a\n1\n2\n3\n4\n5\n6\nb\n", to: "A\n1\n2\n3\n4\n5\n6\nB\n"},
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n-a\n+A\n 1\n 2\n@@ -6,3 +6,3 @@\n 5\n 6\n-b\n+B\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("old", "new", tt.args.from, tt.args.to, 2); got != tt.want {
+				t.Errorf("UnifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
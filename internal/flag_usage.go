@@ -94,38 +94,44 @@ func FlagUsage(flag *pflag.Flag) string {
 }
 
 func defaultValueForFlag(flag *pflag.Flag) string {
+	return DefaultValue(flag.Value.Type(), flag.DefValue, flag.Value.String())
+}
+
+// DefaultValue provides defValue when it differs from the zero value of valueType (as reported by pflag.Value.Type()),
+// otherwise an empty string. The current value is used to detect zero values of types unknown to pflag.
+func DefaultValue(valueType string, defValue string, value string) string {
 	var defaultValue string
-	switch flag.Value.Type() {
+	switch valueType {
 	case "bool":
-		if flag.DefValue != "false" {
-			defaultValue = flag.DefValue
+		if defValue != "false" {
+			defaultValue = defValue
 		}
 	case "duration":
-		if flag.DefValue != "0" && flag.DefValue != "0s" {
-			defaultValue = flag.DefValue
+		if defValue != "0" && defValue != "0s" {
+			defaultValue = defValue
 		}
 	case "int", "int8", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "count", "float32", "float64":
-		if flag.DefValue != "0" {
-			defaultValue = flag.DefValue
+		if defValue != "0" {
+			defaultValue = defValue
 		}
 	case "string":
-		if flag.DefValue != "" {
-			defaultValue = flag.DefValue
+		if defValue != "" {
+			defaultValue = defValue
 		}
 	case "ip", "ipMask", "ipNet":
-		if flag.DefValue != "<nil>" {
-			defaultValue = flag.DefValue
+		if defValue != "<nil>" {
+			defaultValue = defValue
 		}
 	case "intSlice", "stringSlice", "stringArrayValue":
-		if flag.DefValue != "[]" {
-			defaultValue = flag.DefValue
+		if defValue != "[]" {
+			defaultValue = defValue
 		}
 	default:
-		switch flag.Value.String() {
+		switch value {
 		case "false", "<nil>", "", "0":
 			break
 		default:
-			defaultValue = flag.DefValue
+			defaultValue = defValue
 		}
 	}
 	return defaultValue
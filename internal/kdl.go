@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var bareKdlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// KdlNode is a node of a KDL document, see https://kdl.dev
+type KdlNode struct {
+	Name       string
+	Arguments  []interface{}
+	Properties []KdlProperty
+	Children   []KdlNode
+}
+
+// KdlProperty is a property of a KDL node. Properties are written in the order given.
+type KdlProperty struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalKdl encodes nodes as a KDL v2 document, with children indented by four spaces. Supported values are strings,
+// booleans, numbers, and nil.
+func MarshalKdl(nodes []KdlNode) ([]byte, error) {
+	buf := strings.Builder{}
+	if err := writeKdlNodes(&buf, nodes, 0); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+func writeKdlNodes(buf *strings.Builder, nodes []KdlNode, depth int) error {
+	indent := strings.Repeat("    ", depth)
+	for _, node := range nodes {
+		buf.WriteString(indent + kdlIdentifier(node.Name))
+		for _, argument := range node.Arguments {
+			encoded, err := kdlValue(argument)
+			if err != nil {
+				return fmt.Errorf("kdl: node %q: %w", node.Name, err)
+			}
+			buf.WriteString(" " + encoded)
+		}
+		for _, property := range node.Properties {
+			encoded, err := kdlValue(property.Value)
+			if err != nil {
+				return fmt.Errorf("kdl: node %q property %q: %w", node.Name, property.Key, err)
+			}
+			buf.WriteString(fmt.Sprintf(" %s=%s", kdlIdentifier(property.Key), encoded))
+		}
+		if len(node.Children) > 0 {
+			buf.WriteString(" {\n")
+			if err := writeKdlNodes(buf, node.Children, depth+1); err != nil {
+				return err
+			}
+			buf.WriteString(indent + "}")
+		}
+		buf.WriteString("\n")
+	}
+	return nil
+}
+
+func kdlIdentifier(identifier string) string {
+	switch identifier {
+	case "true", "false", "null", "inf", "nan":
+		return kdlString(identifier)
+	}
+	if bareKdlIdentifier.MatchString(identifier) {
+		return identifier
+	}
+	return kdlString(identifier)
+}
+
+func kdlValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "#null", nil
+	case string:
+		return kdlString(v), nil
+	case bool:
+		if v {
+			return "#true", nil
+		}
+		return "#false", nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return kdlFloat(rv.Float()), nil
+	case reflect.String:
+		return kdlString(rv.String()), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", value)
+}
+
+func kdlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "#nan"
+	case math.IsInf(f, 1):
+		return "#inf"
+	case math.IsInf(f, -1):
+		return "#-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// kdlString encodes s as a KDL quoted string
+func kdlString(s string) string {
+	buf := strings.Builder{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				buf.WriteString(fmt.Sprintf(`\u{%x}`, r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
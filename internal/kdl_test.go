@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarshalKdl(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []KdlNode
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  "",
+		},
+		{
+			name: "arguments and properties in order",
+			input: []KdlNode{
+				{Name: "name", Arguments: []interface{}{"simple \"quoted\"\n"}},
+				{
+					Name:      "flag",
+					Arguments: []interface{}{"-v --verbose"},
+					Properties: []KdlProperty{
+						{Key: "help", Value: "be verbose"},
+						{Key: "count", Value: true},
+						{Key: "hide", Value: false},
+						{Key: "default", Value: nil},
+					},
+				},
+				{Name: "numbers", Arguments: []interface{}{1, uint8(2), 1.0, 0.5, math.Inf(1)}},
+			},
+			want: "name \"simple \\\"quoted\\\"\\n\"\n" +
+				"flag \"-v --verbose\" help=\"be verbose\" count=#true hide=#false default=#null\n" +
+				"numbers 1 2 1.0 0.5 #inf\n",
+		},
+		{
+			name: "children are indented",
+			input: []KdlNode{
+				{
+					Name:      "cmd",
+					Arguments: []interface{}{"alpha"},
+					Children: []KdlNode{
+						{Name: "alias", Arguments: []interface{}{"a", "al"}},
+						{Name: "cmd", Arguments: []interface{}{"beta"}, Children: []KdlNode{{Name: "alias", Arguments: []interface{}{"b"}}}},
+					},
+				},
+			},
+			want: "cmd \"alpha\" {\n" +
+				"    alias \"a\" \"al\"\n" +
+				"    cmd \"beta\" {\n" +
+				"        alias \"b\"\n" +
+				"    }\n" +
+				"}\n",
+		},
+		{
+			name: "quotes identifiers which can't be bare",
+			input: []KdlNode{
+				{Name: "two words", Properties: []KdlProperty{{Key: "true", Value: "\x01"}, {Key: "1st", Value: "x"}}},
+			},
+			want: "\"two words\" \"true\"=\"\\u{1}\" \"1st\"=\"x\"\n",
+		},
+		{
+			name:    "unsupported values",
+			input:   []KdlNode{{Name: "node", Arguments: []interface{}{[]string{"a"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalKdl(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalKdl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalKdl() got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
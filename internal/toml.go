@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var bareTomlKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// MarshalToml encodes a map as a TOML document. Keys are sorted, and nested maps are encoded as tables following all
+// other keys of their parent. Supported values are strings, booleans, numbers, time.Time, slices of those, and maps
+// with string keys.
+func MarshalToml(in map[string]interface{}) ([]byte, error) {
+	buf := strings.Builder{}
+	if err := writeTomlTable(&buf, nil, in); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+func writeTomlTable(buf *strings.Builder, path []string, table map[string]interface{}) error {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tables := make([]string, 0)
+	for _, key := range keys {
+		value := table[key]
+		if value == nil {
+			continue
+		}
+		if _, ok := value.(map[string]interface{}); ok {
+			tables = append(tables, key)
+			continue
+		}
+		encoded, err := tomlValue(value)
+		if err != nil {
+			return fmt.Errorf("toml: key %q: %w", strings.Join(append(path, key), "."), err)
+		}
+		buf.WriteString(fmt.Sprintf("%s = %s\n", tomlKey(key), encoded))
+	}
+
+	for _, key := range tables {
+		nested := append(append([]string{}, path...), key)
+		quoted := make([]string, 0, len(nested))
+		for _, part := range nested {
+			quoted = append(quoted, tomlKey(part))
+		}
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(fmt.Sprintf("[%s]\n", strings.Join(quoted, ".")))
+		if err := writeTomlTable(buf, nested, table[key].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tomlKey(key string) string {
+	if bareTomlKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return tomlFloat(rv.Float()), nil
+	case reflect.String:
+		return tomlString(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := tomlValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("unsupported type %T", value)
+}
+
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// tomlString encodes s as a TOML basic string
+func tomlString(s string) string {
+	buf := strings.Builder{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				buf.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMarshalToml(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: map[string]interface{}{},
+			want:  "",
+		},
+		{
+			name: "scalars in sorted order",
+			input: map[string]interface{}{
+				"title":   "simple \"quoted\"\n",
+				"weight":  2,
+				"draft":   false,
+				"ratio":   1.0,
+				"omitted": nil,
+				"date":    time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+				"nan":     math.NaN(),
+			},
+			want: "date = 2023-01-02T03:04:05Z\n" +
+				"draft = false\n" +
+				"nan = nan\n" +
+				"ratio = 1.0\n" +
+				"title = \"simple \\\"quoted\\\"\\n\"\n" +
+				"weight = 2\n",
+		},
+		{
+			name: "arrays and quoted keys",
+			input: map[string]interface{}{
+				"aliases":    []string{"a", "b"},
+				"mixed":      []interface{}{1, "two"},
+				"sidebar id": "\x01",
+			},
+			want: "aliases = [\"a\", \"b\"]\n" +
+				"mixed = [1, \"two\"]\n" +
+				"\"sidebar id\" = \"\\u0001\"\n",
+		},
+		{
+			name: "nested tables follow other keys",
+			input: map[string]interface{}{
+				"menu":  map[string]interface{}{"main": map[string]interface{}{"weight": 1}, "name": "cli"},
+				"title": "simple",
+			},
+			want: "title = \"simple\"\n" +
+				"\n[menu]\nname = \"cli\"\n" +
+				"\n[menu.main]\nweight = 1\n",
+		},
+		{
+			name:    "unsupported types",
+			input:   map[string]interface{}{"fn": func() {}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalToml(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalToml() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalToml() = %q, want %q", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package venom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestName is the name of the file, relative to the output directory, which records every file generated by venom
+const ManifestName = ".venom-manifest.json"
+
+type manifestEntry struct {
+	Format   string `json:"format"`
+	Checksum string `json:"sha256"`
+}
+
+type manifest struct {
+	Files map[string]manifestEntry `json:"files"`
+}
+
+// formatScopedOutput may be implemented by an Output which tracks the format of each file written
+type formatScopedOutput interface {
+	forFormat(format string) Output
+}
+
+// manifestOutput writes to a directory, skipping files whose contents are unchanged and recording every file written
+// in a manifest. Once all formats are written, files recorded by a previous manifest but no longer generated are removed.
+type manifestOutput struct {
+	dir      *DirectoryOutput
+	logger   Logger
+	previous manifest
+	current  manifest
+}
+
+func newManifestOutput(dir *DirectoryOutput, logger Logger) (*manifestOutput, error) {
+	previous, _, err := loadManifest(dir.Root)
+	if err != nil {
+		return nil, err
+	}
+	return &manifestOutput{
+		dir:      dir,
+		logger:   logger,
+		previous: previous,
+		current:  manifest{Files: make(map[string]manifestEntry)},
+	}, nil
+}
+
+// loadManifest reads the manifest from root, returning false when no manifest exists
+func loadManifest(root string) (manifest, bool, error) {
+	result := manifest{Files: make(map[string]manifestEntry)}
+	data, err := os.ReadFile(filepath.Join(root, ManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return result, false, fmt.Errorf("invalid manifest %s: %w", ManifestName, err)
+	}
+	if result.Files == nil {
+		result.Files = make(map[string]manifestEntry)
+	}
+	// files are removed by their recorded names, which must not escape the output directory
+	for name := range result.Files {
+		if !fs.ValidPath(name) || name == "." {
+			return result, false, fmt.Errorf("invalid manifest %s: invalid path %q", ManifestName, name)
+		}
+	}
+	return result, true, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (m *manifestOutput) WriteFile(name string, data []byte) error {
+	return m.write("", name, data)
+}
+
+func (m *manifestOutput) forFormat(format string) Output {
+	return &manifestFormatOutput{manifest: m, format: format}
+}
+
+func (m *manifestOutput) write(format string, name string, data []byte) error {
+	if name == ManifestName {
+		return fmt.Errorf("output path %q is reserved", name)
+	}
+
+	existing, err := os.ReadFile(filepath.Join(m.dir.Root, filepath.FromSlash(name)))
+	if err != nil || !bytes.Equal(existing, data) {
+		if err = m.dir.WriteFile(name, data); err != nil {
+			return err
+		}
+	}
+
+	m.current.Files[name] = manifestEntry{Format: format, Checksum: checksum(data)}
+	return nil
+}
+
+// finish removes files generated by a previous run of any of formats which were not generated by this run, then
+// writes the manifest. Files of other formats are retained in the manifest as-is. Files written without a format are
+// considered part of every run, so are removed unless written again.
+func (m *manifestOutput) finish(formats []string) error {
+	names := make([]string, 0, len(m.previous.Files))
+	for name := range m.previous.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := m.previous.Files[name]
+		if _, ok := m.current.Files[name]; ok {
+			continue
+		}
+		if entry.Format != "" && !contains(formats, entry.Format) {
+			m.current.Files[name] = entry
+			continue
+		}
+
+		target := filepath.Join(m.dir.Root, filepath.FromSlash(name))
+		existing, err := os.ReadFile(target)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// a stale file modified since it was generated is no longer ours to remove
+		if checksum(existing) != entry.Checksum {
+			m.logger.Printf("Keeping stale file %s because it was modified after generation", name)
+			continue
+		}
+
+		if err = os.Remove(target); err != nil {
+			return err
+		}
+		m.logger.Printf("Removed stale file %s", name)
+		m.removeEmptyParents(name)
+	}
+
+	data, err := json.MarshalIndent(m.current, "", "  ")
+	if err != nil {
+		return err
+	}
+	return m.dir.WriteFile(ManifestName, append(data, '\n'))
+}
+
+// removeEmptyParents removes directories left empty by pruning, stopping at the output directory
+func (m *manifestOutput) removeEmptyParents(name string) {
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if err := os.Remove(filepath.Join(m.dir.Root, filepath.FromSlash(dir))); err != nil {
+			// not empty, or otherwise can't be removed
+			return
+		}
+	}
+}
+
+type manifestFormatOutput struct {
+	manifest *manifestOutput
+	format   string
+}
+
+func (m *manifestFormatOutput) WriteFile(name string, data []byte) error {
+	return m.manifest.write(m.format, name, data)
+}
+
+var (
+	_ Output             = (*manifestOutput)(nil)
+	_ Output             = (*manifestFormatOutput)(nil)
+	_ formatScopedOutput = (*manifestOutput)(nil)
+)
@@ -0,0 +1,167 @@
+package venom
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWrite_manifest(t *testing.T) {
+	outDir := t.TempDir()
+	opts := NewOptions().
+		WithFormats(Markdown | Json).
+		WithOutDirectory(outDir).
+		WithGenerationDate(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)).
+		WithLogger(log.New(io.Discard, "", 0))
+
+	write := func(t *testing.T, options *Options, children ...string) {
+		t.Helper()
+		if err := Write(NewDocumentation(newCheckTestCommand(children...), options)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(outDir, filepath.FromSlash(name)))
+		return err == nil
+	}
+
+	write(t, opts, "first", "second", "third")
+
+	unrelated := filepath.Join(outDir, "simple", "notes.md")
+	if err := os.WriteFile(unrelated, []byte("notes"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	modified := filepath.Join(outDir, "simple", "simple_third.md")
+	if err := os.WriteFile(modified, []byte("hand edited"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	unchanged := filepath.Join(outDir, "simple", "simple_first.md")
+	if err := os.Chtimes(unchanged, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	write(t, opts, "first")
+
+	t.Run("records generated files", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outDir, ManifestName))
+		if err != nil {
+			t.Fatalf("expected manifest: %v", err)
+		}
+		m := manifest{}
+		if err = json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("invalid manifest: %v", err)
+		}
+		for name, format := range map[string]string{"simple/simple.md": "markdown", "simple/simple_first.md": "markdown", "simple/index.md": "markdown", "simple/simple.json": "json"} {
+			if m.Files[name].Format != format || m.Files[name].Checksum == "" {
+				t.Errorf("manifest entry for %s = %v", name, m.Files[name])
+			}
+		}
+		if _, ok := m.Files["simple/simple_second.md"]; ok {
+			t.Errorf("manifest retained removed file")
+		}
+	})
+
+	t.Run("skips unchanged files", func(t *testing.T) {
+		info, err := os.Stat(unchanged)
+		if err != nil || !info.ModTime().Equal(past) {
+			t.Errorf("expected unchanged file to retain its modification time, got %v", info.ModTime())
+		}
+	})
+
+	t.Run("removes stale files", func(t *testing.T) {
+		if exists("simple/simple_second.md") {
+			t.Errorf("expected stale file to be removed")
+		}
+	})
+
+	t.Run("never touches other files", func(t *testing.T) {
+		if !exists("simple/notes.md") {
+			t.Errorf("expected unrelated file to remain")
+		}
+		if !exists("simple/simple_third.md") {
+			t.Errorf("expected modified stale file to remain")
+		}
+	})
+
+	t.Run("retains files of formats not written", func(t *testing.T) {
+		write(t, NewOptions().WithFormats(Json).WithOutDirectory(outDir).WithLogger(log.New(io.Discard, "", 0)), "first")
+		if !exists("simple/simple_first.md") || !exists("simple/index.md") {
+			t.Errorf("expected markdown files to remain when only writing json")
+		}
+		m, _, err := loadManifest(outDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := m.Files["simple/simple_first.md"]; !ok {
+			t.Errorf("expected manifest to retain markdown files when only writing json")
+		}
+	})
+	t.Run("removes stale files written without a format", func(t *testing.T) {
+		dir := NewDirectoryOutput(t.TempDir())
+		logger := log.New(io.Discard, "", 0)
+		first, err := newManifestOutput(dir, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = first.WriteFile("extra.txt", []byte("extra")); err != nil {
+			t.Fatal(err)
+		}
+		if err = first.finish([]string{"json"}); err != nil {
+			t.Fatal(err)
+		}
+
+		second, err := newManifestOutput(dir, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = second.finish([]string{"json"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = os.Stat(filepath.Join(dir.Root, "extra.txt")); err == nil {
+			t.Errorf("expected stale file written without a format to be removed")
+		}
+	})
+}
+
+func TestWrite_manifestInvalidPath(t *testing.T) {
+	parent := t.TempDir()
+	outDir := filepath.Join(parent, "docs")
+	if err := os.MkdirAll(outDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	victim := filepath.Join(parent, "victim.txt")
+	if err := os.WriteFile(victim, []byte("victim"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(manifest{Files: map[string]manifestEntry{
+		"../victim.txt": {Format: "markdown", Checksum: checksum([]byte("victim"))},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(outDir, ManifestName), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := NewOptions().
+		WithFormats(Markdown).
+		WithOutDirectory(outDir).
+		WithLogger(log.New(io.Discard, "", 0))
+	doc := NewDocumentation(newCheckTestCommand("first"), opts)
+
+	if err = Write(doc); err == nil {
+		t.Errorf("Write() expected error for manifest entry outside the output directory")
+	}
+	if _, err = Check(doc); err == nil {
+		t.Errorf("Check() expected error for manifest entry outside the output directory")
+	}
+	if _, err = os.Stat(victim); err != nil {
+		t.Errorf("expected file outside the output directory to remain: %v", err)
+	}
+}
@@ -4,11 +4,21 @@ import (
 	"embed"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"gopkg.in/yaml.v3"
 	"io/fs"
 	"log"
+	"os"
+	"strconv"
+	"time"
 )
 
+// DefaultDateLayout is the layout of Documentation.GenerationDate unless otherwise configured
+const DefaultDateLayout = "2-Jan-2006"
+
+// sourceDateEpochEnv is the environment variable defined by https://reproducible-builds.org/specs/source-date-epoch/
+const sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
 //go:embed templates/*.tmpl
 var templates embed.FS
 
@@ -20,13 +30,28 @@ type TemplateOptions struct {
 	StripAnsiInMarkdown      bool
 	MaxOptionWidthInMarkdown int
 	Templates                fs.FS
+	ManSection               string
+	GzipManPages             bool
+	AntoraLayout             bool
+	SphinxConf               bool
+	MkDocs                   bool
+	MdBook                   bool
+	NestedMarkdownLayout     bool
+	HugoLayout               bool
+	FrontMatter              FrontMatterFn
+	FrontMatterFormat        FrontMatterFormat
+	ConcatenatedText         bool
 }
 
 // Options provides a builder-pattern of user-facing optional functionality when constructing via venom.Initialize
 type Options struct {
 	commandName               string
-	formats                   Formats
+	formats                   []string
 	outDir                    string
+	fileMode                  fs.FileMode
+	directoryMode             fs.FileMode
+	generationDate            time.Time
+	dateLayout                string
 	showHiddenCommands        bool
 	disableUserCommandOptions bool
 	templateOptions           *TemplateOptions
@@ -44,13 +69,31 @@ func (o *Options) WithLogger(logger Logger) *Options {
 	return o
 }
 
-// WithFormats allows the caller to define formats which differ from the Options defaults.
+// WithFormats allows the caller to define built-in formats which differ from the Options defaults.
 func (o *Options) WithFormats(formats Formats) *Options {
+	o.formats = formats.names()
+	return o
+}
+
+// WithFormatNames allows the caller to define formats by name or alias, including those added via RegisterFormat.
+// Unknown names are retained so they can be reported by validation.
+func (o *Options) WithFormatNames(names ...string) *Options {
+	formats := make([]string, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if definition, ok := LookupFormat(name); ok {
+			name = definition.Name
+		}
+		if !seen[name] {
+			seen[name] = true
+			formats = append(formats, name)
+		}
+	}
 	o.formats = formats
 	return o
 }
 
-// WithJsonMarshal allows the caller to define a custom JSON marshaling function, default is json.Marshal from the Go standard library.
+// WithJsonMarshal allows the caller to define a custom JSON marshaling function, default is indented output from json.MarshalIndent.
 func (o *Options) WithJsonMarshal(fn MarshalFn) *Options {
 	o.templateOptions.JsonMarshaler = fn
 	return o
@@ -68,6 +111,31 @@ func (o *Options) WithOutDirectory(out string) *Options {
 	return o
 }
 
+// WithFileMode allows the caller to define the mode of files written to the output directory, default is DefaultFileMode.
+func (o *Options) WithFileMode(mode fs.FileMode) *Options {
+	o.fileMode = mode
+	return o
+}
+
+// WithDirectoryMode allows the caller to define the mode of directories created in the output directory, default is DefaultDirectoryMode.
+func (o *Options) WithDirectoryMode(mode fs.FileMode) *Options {
+	o.directoryMode = mode
+	return o
+}
+
+// WithGenerationDate allows the caller to pin the generation date of documentation, default is the value of the
+// SOURCE_DATE_EPOCH environment variable if set, otherwise the current time.
+func (o *Options) WithGenerationDate(date time.Time) *Options {
+	o.generationDate = date
+	return o
+}
+
+// WithDateLayout allows the caller to define the layout (as defined by time.Layout) of the generation date, default is DefaultDateLayout.
+func (o *Options) WithDateLayout(layout string) *Options {
+	o.dateLayout = layout
+	return o
+}
+
 // WithShowHiddenCommands allows the caller to signify whether details about hidden commands should be present in the final output
 func (o *Options) WithShowHiddenCommands() *Options {
 	o.showHiddenCommands = true
@@ -93,6 +161,84 @@ func (o *Options) WithCustomTemplates(fs fs.FS) *Options {
 	return o
 }
 
+// WithManSection allows the caller to define the manual section for generated man pages, default is section 1 (user commands).
+func (o *Options) WithManSection(section string) *Options {
+	o.templateOptions.ManSection = section
+	return o
+}
+
+// WithGzipManPages allows the caller to require man pages be compressed with gzip, as expected by most distributions.
+func (o *Options) WithGzipManPages() *Options {
+	o.templateOptions.GzipManPages = true
+	return o
+}
+
+// WithAntoraLayout allows the caller to lay out AsciiDoc output as an Antora module, writing pages to modules/ROOT/pages
+// and a navigation file to modules/ROOT/nav.adoc.
+func (o *Options) WithAntoraLayout() *Options {
+	o.templateOptions.AntoraLayout = true
+	return o
+}
+
+// WithSphinxConf allows the caller to write a minimal Sphinx conf.py alongside reStructuredText output, so sphinx-build
+// may be run on the output directory directly.
+func (o *Options) WithSphinxConf() *Options {
+	o.templateOptions.SphinxConf = true
+	return o
+}
+
+// WithMkDocs allows the caller to write a mkdocs.yml with navigation for the whole command tree alongside Markdown output.
+// Markdown pages are then written to a docs directory, as MkDocs expects.
+func (o *Options) WithMkDocs() *Options {
+	o.templateOptions.MkDocs = true
+	return o
+}
+
+// WithMdBook allows the caller to write an mdBook SUMMARY.md and book.toml alongside Markdown output.
+// Markdown pages are then written to a src directory, as mdBook expects.
+func (o *Options) WithMdBook() *Options {
+	o.templateOptions.MdBook = true
+	return o
+}
+
+// WithNestedMarkdownLayout allows the caller to write Markdown pages of subcommands to a directory named for their parent
+// command, e.g. app/alpha/gamma.md rather than app_alpha_gamma.md.
+func (o *Options) WithNestedMarkdownLayout() *Options {
+	o.templateOptions.NestedMarkdownLayout = true
+	return o
+}
+
+// WithHugoLayout allows the caller to lay out Markdown pages for Hugo: pages are nested as with WithNestedMarkdownLayout,
+// each command with subcommands is written to an _index.md in its own directory, and the index page is written to _index.md.
+func (o *Options) WithHugoLayout() *Options {
+	o.templateOptions.NestedMarkdownLayout = true
+	o.templateOptions.HugoLayout = true
+	return o
+}
+
+// WithFrontMatter allows the caller to add front matter to every Markdown command and index page. By default, front matter
+// includes the title, description, weight, and aliases of the command; values returned by fn are merged over these
+// defaults, and fn may be nil to use only the defaults.
+func (o *Options) WithFrontMatter(fn FrontMatterFn) *Options {
+	if fn == nil {
+		fn = func(c Command) map[string]interface{} { return nil }
+	}
+	o.templateOptions.FrontMatter = fn
+	return o
+}
+
+// WithFrontMatterFormat allows the caller to define the serialization of front matter, default is FrontMatterYaml.
+func (o *Options) WithFrontMatterFormat(format FrontMatterFormat) *Options {
+	o.templateOptions.FrontMatterFormat = format
+	return o
+}
+
+// WithConcatenatedText allows the caller to write the help of all commands to a single text file, rather than a file per command.
+func (o *Options) WithConcatenatedText() *Options {
+	o.templateOptions.ConcatenatedText = true
+	return o
+}
+
 func (o *Options) WithMaxOptionWidthInMarkdown(width int) *Options {
 	o.templateOptions.MaxOptionWidthInMarkdown = width
 	return o
@@ -103,15 +249,61 @@ func (o *Options) TemplateOptions() TemplateOptions {
 	return *(*o).templateOptions
 }
 
+// generationTime provides the pinned generation date, falling back to SOURCE_DATE_EPOCH and finally the current time
+func (o *Options) generationTime() time.Time {
+	if !o.generationDate.IsZero() {
+		return o.generationDate
+	}
+
+	if epoch, ok := os.LookupEnv(sourceDateEpochEnv); ok && epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+		if o.templateOptions != nil && o.templateOptions.Logger != nil {
+			o.templateOptions.Logger.Printf("Ignoring invalid %s %q: %v", sourceDateEpochEnv, epoch, err)
+		}
+	}
+
+	return time.Now()
+}
+
+// hasGenerationDate reports whether the generation date is pinned, by WithGenerationDate or SOURCE_DATE_EPOCH
+func (o *Options) hasGenerationDate() bool {
+	if !o.generationDate.IsZero() {
+		return true
+	}
+	epoch, ok := os.LookupEnv(sourceDateEpochEnv)
+	if !ok || epoch == "" {
+		return false
+	}
+	_, err := strconv.ParseInt(epoch, 10, 64)
+	return err == nil
+}
+
+// hasFormat determines if the named format is enabled, accepting either a format's name or alias
+func (o *Options) hasFormat(name string) bool {
+	if definition, ok := LookupFormat(name); ok {
+		name = definition.Name
+	}
+	return contains(o.formats, name)
+}
+
 func (o *Options) validate() error {
 	if o.commandName == "" {
 		return errors.New("command name can't be empty")
 	}
 
-	if !o.formats.IsValid() {
+	if len(o.formats) == 0 {
 		return errors.New("invalid documentation format(s) provided")
 	}
 
+	for _, format := range o.formats {
+		if _, ok := LookupFormat(format); !ok {
+			return fmt.Errorf("invalid documentation format %q provided", format)
+		}
+	}
+
 	if o.templateOptions.JsonMarshaler == nil {
 		return errors.New("invalid json marshal provided")
 	}
@@ -128,25 +320,43 @@ func (o *Options) validate() error {
 		return errors.New("invalid templates provided")
 	}
 
+	if o.templateOptions.FrontMatterFormat != "" && !o.templateOptions.FrontMatterFormat.IsValid() {
+		return fmt.Errorf("invalid front matter format %q provided", o.templateOptions.FrontMatterFormat)
+	}
+
 	if o.templateOptions.MaxOptionWidthInMarkdown < 24 {
 		return errors.New("invalid max options width in markdown provided; minimum is 24")
 	}
 	return nil
 }
 
+// marshalJsonIndent produces stable, human-readable JSON. Struct fields are emitted in declaration order and map keys are sorted.
+func marshalJsonIndent(in interface{}) ([]byte, error) {
+	out, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
 // NewOptions provides a new set of options with default command name ("docs") and formats (Markdown).
 // The value returned here follows the builder pattern for easily discovering and applying available options.
 func NewOptions() *Options {
 	return &Options{
-		commandName: "docs",
-		formats:     Markdown,
-		outDir:      "docs",
+		commandName:   "docs",
+		formats:       Markdown.names(),
+		outDir:        "docs",
+		fileMode:      DefaultFileMode,
+		directoryMode: DefaultDirectoryMode,
+		dateLayout:    DefaultDateLayout,
 		templateOptions: &TemplateOptions{
-			JsonMarshaler:            json.Marshal,
+			JsonMarshaler:            marshalJsonIndent,
 			YamlMarshaler:            yaml.Marshal,
 			Logger:                   log.Default(),
 			Templates:                templates,
 			MaxOptionWidthInMarkdown: 120,
+			ManSection:               "1",
+			FrontMatterFormat:        FrontMatterYaml,
 		},
 	}
 }
@@ -16,9 +16,11 @@ func TestOptions_validate(t *testing.T) {
 	type fields struct {
 		commandName   string
 		formats       Formats
+		formatNames   []string
 		logger        *log.Logger
 		jsonMarshaler MarshalFn
 		yamlMarshaler MarshalFn
+		frontMatter   FrontMatterFormat
 	}
 	tests := []struct {
 		name    string
@@ -48,6 +50,31 @@ func TestOptions_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "validate fails for unregistered format names",
+			fields: fields{
+				commandName: "asdf",
+				formatNames: []string{"markdown", "unregistered"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validate succeeds for registered format names and aliases",
+			fields: fields{
+				commandName: "asdf",
+				formatNames: []string{"md", "yaml"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validate fails for unsupported front matter format",
+			fields: fields{
+				commandName: "asdf",
+				formats:     Markdown,
+				frontMatter: FrontMatterFormat("json"),
+			},
+			wantErr: true,
+		},
 		{
 			name: "validate succeeds for valid inputs",
 			fields: fields{
@@ -65,6 +92,7 @@ func TestOptions_validate(t *testing.T) {
 				JsonMarshaler:            defaultTemplateOptions.JsonMarshaler,
 				Templates:                templates,
 				MaxOptionWidthInMarkdown: 120,
+				FrontMatterFormat:        tt.fields.frontMatter,
 			}
 
 			if tt.fields.logger != nil {
@@ -81,9 +109,12 @@ func TestOptions_validate(t *testing.T) {
 
 			o := &Options{
 				commandName:     tt.fields.commandName,
-				formats:         tt.fields.formats,
+				formats:         tt.fields.formats.names(),
 				templateOptions: &opts,
 			}
+			if tt.fields.formatNames != nil {
+				o.WithFormatNames(tt.fields.formatNames...)
+			}
 			if err := o.validate(); (err != nil) != tt.wantErr {
 				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,213 @@
+package venom
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	// DefaultFileMode is the mode applied to generated files unless otherwise configured
+	DefaultFileMode fs.FileMode = 0644
+	// DefaultDirectoryMode is the mode applied to generated directories unless otherwise configured
+	DefaultDirectoryMode fs.FileMode = 0755
+)
+
+// Output is the destination for generated documentation files.
+// Names are always slash-separated and relative to the root of the output, as defined by fs.ValidPath.
+type Output interface {
+	WriteFile(name string, data []byte) error
+}
+
+// DirectoryOutput writes files beneath Root on the local disk. Each file is written atomically by writing to a
+// temporary file in the target directory, then renaming over the target.
+type DirectoryOutput struct {
+	Root          string
+	FileMode      fs.FileMode
+	DirectoryMode fs.FileMode
+}
+
+// NewDirectoryOutput creates a DirectoryOutput rooted at root using DefaultFileMode and DefaultDirectoryMode
+func NewDirectoryOutput(root string) *DirectoryOutput {
+	return &DirectoryOutput{
+		Root:          root,
+		FileMode:      DefaultFileMode,
+		DirectoryMode: DefaultDirectoryMode,
+	}
+}
+
+// WriteFile atomically writes data to name beneath Root, creating any parent directories
+func (d *DirectoryOutput) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("invalid output path %q", name)
+	}
+
+	target := filepath.Join(d.Root, filepath.FromSlash(name))
+	dir := filepath.Dir(target)
+	if err := os.MkdirAll(dir, d.DirectoryMode); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	// remove the temp file on any failure; after a successful rename this is a no-op
+	defer func() {
+		_ = os.Remove(tmpName)
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(d.FileMode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, target)
+}
+
+// MemoryOutput collects all written files in memory, keyed by name
+type MemoryOutput struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemoryOutput creates an empty MemoryOutput
+func NewMemoryOutput() *MemoryOutput {
+	return &MemoryOutput{files: make(map[string][]byte)}
+}
+
+// WriteFile stores a copy of data under name, replacing any previous contents
+func (m *MemoryOutput) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("invalid output path %q", name)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Files provides all files written so far
+func (m *MemoryOutput) Files() map[string][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make(map[string][]byte, len(m.files))
+	for name, data := range m.files {
+		result[name] = data
+	}
+	return result
+}
+
+// Names provides the sorted names of all files written so far
+func (m *MemoryOutput) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TarGzOutput writes files as entries of a gzip-compressed tar archive. Close must be called to flush the archive.
+type TarGzOutput struct {
+	FileMode fs.FileMode
+	gz       *gzip.Writer
+	tw       *tar.Writer
+}
+
+// NewTarGzOutput creates a TarGzOutput writing the archive to w
+func NewTarGzOutput(w io.Writer) *TarGzOutput {
+	gz := gzip.NewWriter(w)
+	return &TarGzOutput{
+		FileMode: DefaultFileMode,
+		gz:       gz,
+		tw:       tar.NewWriter(gz),
+	}
+}
+
+// WriteFile adds data to the archive as name
+func (t *TarGzOutput) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("invalid output path %q", name)
+	}
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(t.FileMode.Perm()),
+		Size:     int64(len(data)),
+	}); err != nil {
+		return err
+	}
+	_, err := t.tw.Write(data)
+	return err
+}
+
+// Close finalizes the archive. It does not close the underlying writer.
+func (t *TarGzOutput) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gz.Close()
+}
+
+// ZipOutput writes files as entries of a zip archive. Close must be called to flush the archive.
+type ZipOutput struct {
+	FileMode fs.FileMode
+	zw       *zip.Writer
+}
+
+// NewZipOutput creates a ZipOutput writing the archive to w
+func NewZipOutput(w io.Writer) *ZipOutput {
+	return &ZipOutput{
+		FileMode: DefaultFileMode,
+		zw:       zip.NewWriter(w),
+	}
+}
+
+// WriteFile adds data to the archive as name
+func (z *ZipOutput) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return fmt.Errorf("invalid output path %q", name)
+	}
+	header := &zip.FileHeader{
+		Name:   name,
+		Method: zip.Deflate,
+	}
+	header.SetMode(z.FileMode)
+	f, err := z.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// Close finalizes the archive. It does not close the underlying writer.
+func (z *ZipOutput) Close() error {
+	return z.zw.Close()
+}
+
+var (
+	_ Output = (*DirectoryOutput)(nil)
+	_ Output = (*MemoryOutput)(nil)
+	_ Output = (*TarGzOutput)(nil)
+	_ Output = (*ZipOutput)(nil)
+)
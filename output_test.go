@@ -0,0 +1,176 @@
+package venom
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"github.com/go-test/deep"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+var testOutputFiles = map[string][]byte{
+	"simple/simple.md":         []byte("# simple"),
+	"simple/simple_command.md": []byte("# simple command"),
+}
+
+func TestDirectoryOutput_WriteFile(t *testing.T) {
+	root := t.TempDir()
+	out := NewDirectoryOutput(root)
+	out.FileMode = 0600
+	out.DirectoryMode = 0700
+
+	for name, data := range testOutputFiles {
+		if err := out.WriteFile(name, data); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+
+	// overwriting replaces contents
+	if err := out.WriteFile("simple/simple.md", []byte("# replaced")); err != nil {
+		t.Fatalf("WriteFile() overwrite error = %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "simple"))
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != len(testOutputFiles) {
+		t.Errorf("WriteFile() left unexpected files behind: %v", entries)
+	}
+
+	b, err := os.ReadFile(filepath.Join(root, "simple", "simple.md"))
+	if err != nil || string(b) != "# replaced" {
+		t.Errorf("WriteFile() contents = %q, err = %v", string(b), err)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(root, "simple", "simple_command.md"))
+		if err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("WriteFile() file mode = %v, err = %v", info.Mode().Perm(), err)
+		}
+		info, err = os.Stat(filepath.Join(root, "simple"))
+		if err != nil || info.Mode().Perm() != 0700 {
+			t.Errorf("WriteFile() directory mode = %v, err = %v", info.Mode().Perm(), err)
+		}
+	}
+
+	for _, invalid := range []string{"../escape.md", "/absolute.md"} {
+		if err := out.WriteFile(invalid, nil); err == nil {
+			t.Errorf("WriteFile(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestMemoryOutput_WriteFile(t *testing.T) {
+	out := NewMemoryOutput()
+	for name, data := range testOutputFiles {
+		if err := out.WriteFile(name, data); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+
+	if diff := deep.Equal(out.Files(), testOutputFiles); diff != nil {
+		t.Errorf("Files() = %v", diff)
+	}
+	if diff := deep.Equal(out.Names(), []string{"simple/simple.md", "simple/simple_command.md"}); diff != nil {
+		t.Errorf("Names() = %v", diff)
+	}
+}
+
+func TestTarGzOutput_WriteFile(t *testing.T) {
+	buf := bytes.Buffer{}
+	out := NewTarGzOutput(&buf)
+	for name, data := range testOutputFiles {
+		if err := out.WriteFile(name, data); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+	if err := out.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	got := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar Next() error = %v", err)
+		}
+		if fs.FileMode(header.Mode) != DefaultFileMode {
+			t.Errorf("tar entry %s mode = %v", header.Name, fs.FileMode(header.Mode))
+		}
+		got[header.Name], _ = io.ReadAll(tr)
+	}
+
+	if diff := deep.Equal(got, testOutputFiles); diff != nil {
+		t.Errorf("TarGzOutput contents = %v", diff)
+	}
+}
+
+func TestZipOutput_WriteFile(t *testing.T) {
+	buf := bytes.Buffer{}
+	out := NewZipOutput(&buf)
+	for name, data := range testOutputFiles {
+		if err := out.WriteFile(name, data); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+	if err := out.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	got := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("zip Open(%s) error = %v", f.Name, err)
+		}
+		got[f.Name], _ = io.ReadAll(rc)
+		_ = rc.Close()
+	}
+
+	if diff := deep.Equal(got, testOutputFiles); diff != nil {
+		t.Errorf("ZipOutput contents = %v", diff)
+	}
+}
+
+func TestRender(t *testing.T) {
+	opts := NewOptions().WithFormats(Markdown | Json)
+	doc := Documentation{
+		GenerationDate: "1-Jan-2023",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Short:    "s",
+		},
+		options: opts,
+	}
+
+	files, err := Render(doc)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, name := range []string{"simple/simple.md", "simple/index.md", "simple/simple.json"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Render() missing %s, got %v", name, files)
+		}
+	}
+}
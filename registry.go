@@ -0,0 +1,96 @@
+package venom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FormatDefinition describes a documentation format available for output
+type FormatDefinition struct {
+	// Name is the canonical, lower-case name of the format
+	Name string
+	// Aliases are alternative names accepted for the format, for example by the --formats flag of the docs command
+	Aliases []string
+	// Extension is the file extension (without leading dot) of files output by the format
+	Extension string
+	writer    WriterFn
+}
+
+type formatRegistry struct {
+	mu      sync.RWMutex
+	ordered []FormatDefinition
+	lookup  map[string]int
+}
+
+var registry = &formatRegistry{lookup: make(map[string]int)}
+
+// RegisterFormat makes a format available for output under name and any aliases. Names are case-insensitive, and
+// neither name nor aliases may conflict with those of previously registered formats.
+func RegisterFormat(name string, aliases []string, extension string, writer WriterFn) error {
+	return registry.register(name, aliases, extension, writer)
+}
+
+// RegisteredFormats provides definitions of all formats in the order they were registered
+func RegisteredFormats() []FormatDefinition {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	result := make([]FormatDefinition, len(registry.ordered))
+	copy(result, registry.ordered)
+	return result
+}
+
+// LookupFormat finds a registered format by its name or any alias
+func LookupFormat(nameOrAlias string) (FormatDefinition, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	if idx, ok := registry.lookup[strings.ToLower(nameOrAlias)]; ok {
+		return registry.ordered[idx], true
+	}
+	return FormatDefinition{}, false
+}
+
+func (r *formatRegistry) register(name string, aliases []string, extension string, writer WriterFn) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return errors.New("format name can't be empty")
+	}
+	if writer == nil {
+		return fmt.Errorf("format %q requires a writer", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := []string{name}
+	for _, alias := range aliases {
+		if alias = strings.ToLower(strings.TrimSpace(alias)); alias != "" {
+			keys = append(keys, alias)
+		}
+	}
+
+	for _, key := range keys {
+		if idx, exists := r.lookup[key]; exists {
+			return fmt.Errorf("format %q conflicts with registered format %q", key, r.ordered[idx].Name)
+		}
+	}
+
+	r.ordered = append(r.ordered, FormatDefinition{
+		Name:      name,
+		Aliases:   keys[1:],
+		Extension: extension,
+		writer:    writer,
+	})
+	for _, key := range keys {
+		r.lookup[key] = len(r.ordered) - 1
+	}
+	return nil
+}
+
+// mustRegisterFormat registers a built-in format, using its lower-cased name as the canonical format name
+func mustRegisterFormat(name string, aliases []string, extension string, writer WriterFn) {
+	if err := RegisterFormat(strings.ToLower(name), aliases, extension, writer); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,106 @@
+package venom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type writerTestRegistry struct {
+	options TemplateOptions
+}
+
+func (w *writerTestRegistry) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func (w *writerTestRegistry) Write(out Output, doc Documentation) error {
+	return out.WriteFile(doc.RootCommand.Name+".registry", []byte(doc.RootCommand.Short))
+}
+
+func TestRegisterFormat(t *testing.T) {
+	newWriter := func() Writer {
+		return &writerTestRegistry{}
+	}
+	type args struct {
+		name    string
+		aliases []string
+		writer  WriterFn
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "registers a new format",
+			args:    args{name: "Test-Registry", aliases: []string{"test-reg"}, writer: newWriter},
+			wantErr: false,
+		},
+		{
+			name:    "fails for empty name",
+			args:    args{name: " ", writer: newWriter},
+			wantErr: true,
+		},
+		{
+			name:    "fails for missing writer",
+			args:    args{name: "test-registry-no-writer"},
+			wantErr: true,
+		},
+		{
+			name:    "fails for conflicting name",
+			args:    args{name: "markdown", writer: newWriter},
+			wantErr: true,
+		},
+		{
+			name:    "fails for conflicting alias",
+			args:    args{name: "test-registry-alias", aliases: []string{"yml"}, writer: newWriter},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterFormat(tt.args.name, tt.args.aliases, "registry", tt.args.writer); (err != nil) != tt.wantErr {
+				t.Errorf("RegisterFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("looks up by name and alias", func(t *testing.T) {
+		for _, key := range []string{"test-registry", "TEST-REG"} {
+			definition, ok := LookupFormat(key)
+			if !ok || definition.Name != "test-registry" || definition.Extension != "registry" {
+				t.Errorf("LookupFormat(%q) = %v, %v", key, definition, ok)
+			}
+		}
+	})
+
+	t.Run("built-in formats are registered by name", func(t *testing.T) {
+		for _, format := range builtinFormats {
+			if _, ok := LookupFormat(format.String()); !ok {
+				t.Errorf("LookupFormat(%q) not registered", format.String())
+			}
+		}
+		for _, name := range []string{"html", "asciidoc", "mdx", "texinfo", "latex", "docbook", "text", "fig", "usagespec", "carapace", "nushell", "maml"} {
+			if _, ok := LookupFormat(name); !ok {
+				t.Errorf("LookupFormat(%q) not registered", name)
+			}
+		}
+	})
+
+	t.Run("writes registered format", func(t *testing.T) {
+		outDir := t.TempDir()
+		opts := NewOptions().WithFormatNames("test-reg").WithOutDirectory(outDir)
+		doc := Documentation{
+			RootCommand: Command{Name: "simple", Short: "registered"},
+			options:     opts,
+		}
+		if err := Write(doc); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		b, err := os.ReadFile(filepath.Join(outDir, "simple.registry"))
+		if err != nil || string(b) != "registered" {
+			t.Errorf("Write() did not invoke the registered writer: %v", err)
+		}
+	})
+}
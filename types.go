@@ -2,9 +2,11 @@ package venom
 
 import (
 	"bytes"
+	"encoding/json"
 	"github.com/jimschubert/venom/internal"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"sort"
 	"strings"
 	"time"
 )
@@ -34,12 +36,110 @@ func (d *Documentation) Write() error {
 	return nil
 }
 
+// Check these docs against those in the output directory
+func (d *Documentation) Check() (*CheckResult, error) {
+	if d != nil {
+		return Check(*d)
+	}
+	return &CheckResult{}, nil
+}
+
 func (d *Documentation) init() {
 	if d.GenerationDate == "" {
-		d.GenerationDate = time.Now().Format("2-Jan-2006")
+		layout := DefaultDateLayout
+		generated := time.Now()
+		if d.options != nil {
+			if d.options.dateLayout != "" {
+				layout = d.options.dateLayout
+			}
+			generated = d.options.generationTime()
+		}
+		d.GenerationDate = generated.Format(layout)
+	}
+}
+
+// ArgsAnnotation is the cobra.Command annotation key from which positional arguments are read, as a JSON array of Arg.
+// See DescribeArgs.
+const ArgsAnnotation = "venom.args"
+
+// Arg describes a positional argument of a command
+type Arg struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool     `yaml:"required" json:"required"`
+	Variadic    bool     `yaml:"variadic" json:"variadic"`
+	Values      []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// DescribeArgs documents the positional arguments of cmd, storing them in the ArgsAnnotation of cmd
+func DescribeArgs(cmd *cobra.Command, args ...Arg) *cobra.Command {
+	// marshaling a slice of Arg can't fail
+	data, _ := json.Marshal(args)
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
 	}
+	cmd.Annotations[ArgsAnnotation] = string(data)
+	return cmd
+}
+
+// FlagGroupKind is the kind of constraint cobra applies to a group of flags
+type FlagGroupKind string
+
+const (
+	// FlagGroupMutuallyExclusive allows at most one flag of the group, see cobra.Command#MarkFlagsMutuallyExclusive
+	FlagGroupMutuallyExclusive FlagGroupKind = "mutuallyExclusive"
+	// FlagGroupRequiredTogether requires all flags of the group if any is set, see cobra.Command#MarkFlagsRequiredTogether
+	FlagGroupRequiredTogether FlagGroupKind = "requiredTogether"
+	// FlagGroupOneRequired requires at least one flag of the group, see cobra.Command#MarkFlagsOneRequired
+	FlagGroupOneRequired FlagGroupKind = "oneRequired"
+)
+
+// flagGroupAnnotations maps each kind to the flag annotation cobra uses to record the group, in order of presentation
+var flagGroupAnnotations = []struct {
+	kind       FlagGroupKind
+	annotation string
+}{
+	{FlagGroupMutuallyExclusive, "cobra_annotation_mutually_exclusive"},
+	{FlagGroupRequiredTogether, "cobra_annotation_required_if_others_set"},
+	{FlagGroupOneRequired, "cobra_annotation_one_required"},
 }
 
+// Description provides a human-readable description of the constraint
+func (k FlagGroupKind) Description() string {
+	switch k {
+	case FlagGroupMutuallyExclusive:
+		return "Mutually exclusive"
+	case FlagGroupRequiredTogether:
+		return "Required together"
+	case FlagGroupOneRequired:
+		return "At least one required"
+	default:
+		return string(k)
+	}
+}
+
+// FlagGroup describes a constraint cobra applies to a group of flags
+type FlagGroup struct {
+	Kind  FlagGroupKind `yaml:"kind" json:"kind"`
+	Flags []string      `yaml:"flags" json:"flags"`
+}
+
+// CommandGroup is a representation of cobra.Group
+type CommandGroup struct {
+	ID    string `yaml:"id" json:"id"`
+	Title string `yaml:"title" json:"title"`
+}
+
+// SubcommandGroup is a set of visible subcommands listed under a common title
+type SubcommandGroup struct {
+	// Title of the group, without any trailing colon. Empty when the parent command defines no groups.
+	Title    string
+	Commands []Command
+}
+
+// ungroupedTitle matches the title cobra uses in help output for subcommands without a group
+const ungroupedTitle = "Additional Commands"
+
 // ParentCommand provides the name of a command's parent
 type ParentCommand struct {
 	Name     string `yaml:"name,omitempty" json:"name,omitempty"`
@@ -56,8 +156,10 @@ type Command struct {
 	Short           string            `yaml:"short,omitempty" json:"short,omitempty"`
 	Long            string            `yaml:"long,omitempty" json:"long,omitempty"`
 	GroupID         string            `yaml:"groupID,omitempty" json:"groupID,omitempty"`
+	Groups          []CommandGroup    `yaml:"groups,omitempty" json:"groups,omitempty"`
 	ValidArgs       []string          `yaml:"validArgs,omitempty" json:"validArgs,omitempty"`
 	ArgAliases      []string          `yaml:"argAliases,omitempty" json:"argAliases,omitempty"`
+	Args            []Arg             `yaml:"args,omitempty" json:"args,omitempty"`
 	Deprecated      string            `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 	Annotations     map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
 	Version         string            `yaml:"version,omitempty" json:"version,omitempty"`
@@ -69,24 +171,73 @@ type Command struct {
 	LocalFlags      []Flag            `yaml:"localFlags,omitempty" json:"localFlags,omitempty"`
 	InheritedFlags  []Flag            `yaml:"inheritedFlags,omitempty" json:"inheritedFlags,omitempty"`
 	PersistentFlags []Flag            `yaml:"persistentFlags,omitempty" json:"persistentFlags,omitempty"`
+	FlagGroups      []FlagGroup       `yaml:"flagGroups,omitempty" json:"flagGroups,omitempty"`
 	Examples        []string          `yaml:"examples,omitempty" json:"examples,omitempty"`
 	FullPath        string            `yaml:"fullPath,omitempty" json:"fullPath,omitempty"`
 }
 
 // Flag is a representation of pflag.Flag
 type Flag struct {
-	Name                string `json:"name,omitempty" yaml:"name,omitempty"`
-	Shorthand           string `json:"shorthand,omitempty" yaml:"shorthand,omitempty"`
-	Usage               string `json:"usage,omitempty" yaml:"usage,omitempty"`
-	DefValue            string `json:"defValue,omitempty" yaml:"defValue,omitempty"`
-	NoOptDefVal         string `json:"noOptDefVal,omitempty" yaml:"noOptDefVal,omitempty"`
-	Deprecated          string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	Hidden              bool   `json:"hidden,omitempty" yaml:"hidden,omitempty"`
-	ShorthandDeprecated string `json:"shorthandDeprecated,omitempty" yaml:"shorthandDeprecated,omitempty"`
-	Inherited           bool   `json:"inherited,omitempty" yaml:"inherited,omitempty"`
-	Persistent          bool   `json:"persistent,omitempty" yaml:"persistent,omitempty"`
-	Local               bool   `json:"local,omitempty" yaml:"local,omitempty"`
-	RawUsage            string `json:"rawUsage,omitempty" yaml:"rawUsage,omitempty"`
+	Name                string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Shorthand           string              `json:"shorthand,omitempty" yaml:"shorthand,omitempty"`
+	Usage               string              `json:"usage,omitempty" yaml:"usage,omitempty"`
+	DefValue            string              `json:"defValue,omitempty" yaml:"defValue,omitempty"`
+	NoOptDefVal         string              `json:"noOptDefVal,omitempty" yaml:"noOptDefVal,omitempty"`
+	Deprecated          string              `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Hidden              bool                `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	ShorthandDeprecated string              `json:"shorthandDeprecated,omitempty" yaml:"shorthandDeprecated,omitempty"`
+	Inherited           bool                `json:"inherited,omitempty" yaml:"inherited,omitempty"`
+	Persistent          bool                `json:"persistent,omitempty" yaml:"persistent,omitempty"`
+	Local               bool                `json:"local,omitempty" yaml:"local,omitempty"`
+	RawUsage            string              `json:"rawUsage,omitempty" yaml:"rawUsage,omitempty"`
+	Type                string              `json:"type,omitempty" yaml:"type,omitempty"`
+	Required            bool                `json:"required,omitempty" yaml:"required,omitempty"`
+	Annotations         map[string][]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// GroupedSubcommands provides visible subcommands under their group titles, in the same order as cobra's help output.
+// Subcommands without a known group are listed last under "Additional Commands". When no groups are defined, all visible
+// subcommands are provided in a single untitled group.
+func (c Command) GroupedSubcommands() []SubcommandGroup {
+	visible := make([]Command, 0, len(c.Subcommands))
+	for _, sub := range c.Subcommands {
+		if !sub.Hidden {
+			visible = append(visible, sub)
+		}
+	}
+
+	if len(c.Groups) == 0 {
+		if len(visible) == 0 {
+			return []SubcommandGroup{}
+		}
+		return []SubcommandGroup{{Commands: visible}}
+	}
+
+	result := make([]SubcommandGroup, 0, len(c.Groups)+1)
+	grouped := make(map[string]bool)
+	for _, group := range c.Groups {
+		current := SubcommandGroup{Title: strings.TrimSuffix(strings.TrimSpace(group.Title), ":")}
+		for _, sub := range visible {
+			if sub.GroupID == group.ID {
+				current.Commands = append(current.Commands, sub)
+				grouped[sub.FullPath] = true
+			}
+		}
+		if len(current.Commands) > 0 {
+			result = append(result, current)
+		}
+	}
+
+	ungrouped := SubcommandGroup{Title: ungroupedTitle}
+	for _, sub := range visible {
+		if !grouped[sub.FullPath] {
+			ungrouped.Commands = append(ungrouped.Commands, sub)
+		}
+	}
+	if len(ungrouped.Commands) > 0 {
+		result = append(result, ungrouped)
+	}
+	return result
 }
 
 func postProcessFlags(flags []*Flag) []*Flag {
@@ -113,6 +264,38 @@ func postProcessFlags(flags []*Flag) []*Flag {
 	return flags
 }
 
+// processFlagGroups extracts the groups cobra records as annotations on each flag of flagSet. As in cobra's validation,
+// groups are only considered when every member flag is defined.
+func processFlagGroups(flagSet *pflag.FlagSet) []FlagGroup {
+	result := make([]FlagGroup, 0)
+	for _, group := range flagGroupAnnotations {
+		members := make(map[string]bool)
+		flagSet.VisitAll(func(cobraFlag *pflag.Flag) {
+			for _, value := range cobraFlag.Annotations[group.annotation] {
+				members[value] = true
+			}
+		})
+
+		keys := make([]string, 0, len(members))
+		for key := range members {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+	next:
+		for _, key := range keys {
+			names := strings.Split(key, " ")
+			for _, name := range names {
+				if flagSet.Lookup(name) == nil {
+					continue next
+				}
+			}
+			result = append(result, FlagGroup{Kind: group.kind, Flags: names})
+		}
+	}
+	return result
+}
+
 func filterFlags(flags []*Flag, fn func(f *Flag) bool) []Flag {
 	result := make([]Flag, 0)
 	for _, flag := range flags {
@@ -137,6 +320,17 @@ func processFlags(flagSet *pflag.FlagSet, fn func(f *Flag)) []*Flag {
 				Hidden:              cobraFlag.Hidden,
 				ShorthandDeprecated: cobraFlag.ShorthandDeprecated,
 				RawUsage:            internal.FlagUsage(cobraFlag),
+				Type:                cobraFlag.Value.Type(),
+			}
+
+			if len(cobraFlag.Annotations) > 0 {
+				current.Annotations = make(map[string][]string, len(cobraFlag.Annotations))
+				for key, values := range cobraFlag.Annotations {
+					current.Annotations[key] = append([]string(nil), values...)
+				}
+				// see cobra.Command#MarkFlagRequired
+				required := cobraFlag.Annotations[cobra.BashCompOneRequiredFlag]
+				current.Required = len(required) > 0 && required[0] == "true"
 			}
 
 			if fn != nil {
@@ -161,9 +355,18 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 		}
 	}
 
+	var args []Arg
 	annotations := make(map[string]string)
 	if len(cmd.Annotations) > 0 {
 		for key, value := range cmd.Annotations {
+			if key == ArgsAnnotation {
+				if err := json.Unmarshal([]byte(value), &args); err != nil {
+					options.templateOptions.Logger.Printf("Ignoring invalid %s annotation on command %q: %v", ArgsAnnotation, cmd.Name(), err)
+					// a value of the wrong type may leave the args partially decoded
+					args = nil
+				}
+				continue
+			}
 			annotations[key] = value
 		}
 	}
@@ -172,7 +375,6 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 
 	command := Command{
 		Name:          cmd.Name(),
-		Usage:         cmd.UseLine(),
 		Parent:        parentCommand,
 		Aliases:       cmd.Aliases,
 		SuggestFor:    cmd.SuggestFor,
@@ -181,6 +383,7 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 		GroupID:       cmd.GroupID,
 		ValidArgs:     cmd.ValidArgs,
 		ArgAliases:    cmd.ArgAliases,
+		Args:          args,
 		Deprecated:    cmd.Deprecated,
 		Annotations:   annotations,
 		Version:       cmd.Version,
@@ -221,7 +424,9 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 		subcommands = append(subcommands, sub)
 	}
 
-	cmd.Groups()
+	for _, group := range cmd.Groups() {
+		command.Groups = append(command.Groups, CommandGroup{ID: group.ID, Title: group.Title})
+	}
 
 	command.Subcommands = subcommands
 
@@ -239,6 +444,9 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 	// aligns all columns across all flag types
 	postProcessFlags(tmp)
 
+	// the use line includes [flags] for inherited flags only once they're merged, as done by processing them above
+	command.Usage = cmd.UseLine()
+
 	command.LocalFlags = filterFlags(tmp, func(f *Flag) bool {
 		return f.Local
 	})
@@ -249,6 +457,9 @@ func NewCommandFromCobra(cmd *cobra.Command, options *Options) Command {
 		return f.Persistent
 	})
 
+	// local and inherited flags are merged into cmd.Flags() by the above
+	command.FlagGroups = processFlagGroups(cmd.Flags())
+
 	return command
 }
 
@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 	"strings"
 	"testing"
+	"time"
 )
 
 func withChildren(cmd *cobra.Command, children ...*cobra.Command) *cobra.Command {
@@ -30,6 +31,9 @@ func withDefaults(command *Command) Command {
 	if command.Annotations == nil {
 		command.Annotations = map[string]string{}
 	}
+	if command.FlagGroups == nil {
+		command.FlagGroups = []FlagGroup{}
+	}
 	return *command
 }
 
@@ -84,6 +88,7 @@ func TestNewCommandFromCobra(t *testing.T) {
 						Inherited:   false,
 						RawUsage:    "      --first         first flag (default true)",
 						Local:       true,
+						Type:        "bool",
 					},
 					{
 						Name:        "second",
@@ -92,6 +97,7 @@ func TestNewCommandFromCobra(t *testing.T) {
 						NoOptDefVal: "true",
 						RawUsage:    "      --second        second flag",
 						Local:       true,
+						Type:        "bool",
 					},
 					{
 						Name:     "third",
@@ -99,6 +105,54 @@ func TestNewCommandFromCobra(t *testing.T) {
 						DefValue: "5",
 						RawUsage: "      --third int8    third flag (default 5)",
 						Local:    true,
+						Type:     "int8",
+					},
+				},
+			}),
+		},
+		{
+			name: "Simple command, no children, with required and annotated flags",
+			args: args{cmd: func() *cobra.Command {
+				command := cobra.Command{
+					Use:   "pinky",
+					Short: "p",
+				}
+
+				command.Flags().StringSlice("names", nil, "names flag")
+				_ = command.MarkFlagRequired("names")
+				command.Flags().String("file", "", "file flag")
+				_ = command.MarkFlagFilename("file", "yaml")
+
+				return &command
+			}()},
+			want: withDefaults(&Command{
+				Name:          "pinky",
+				FullPath:      "pinky",
+				Usage:         "pinky [flags]",
+				Short:         "p",
+				RawFlagUsages: "      --file string     file flag\n      --names strings   names flag",
+				LocalFlags: []Flag{
+					{
+						Name:     "file",
+						Usage:    "file flag",
+						RawUsage: "      --file string      file flag",
+						Local:    true,
+						Type:     "string",
+						Annotations: map[string][]string{
+							cobra.BashCompFilenameExt: {"yaml"},
+						},
+					},
+					{
+						Name:     "names",
+						Usage:    "names flag",
+						DefValue: "[]",
+						RawUsage: "      --names strings    names flag",
+						Local:    true,
+						Type:     "stringSlice",
+						Required: true,
+						Annotations: map[string][]string{
+							cobra.BashCompOneRequiredFlag: {"true"},
+						},
 					},
 				},
 			}),
@@ -172,6 +226,52 @@ func TestNewCommandFromCobra(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "Command with described args",
+			args: args{cmd: DescribeArgs(&cobra.Command{
+				Use:         "sample NAME [FILE...]",
+				Annotations: map[string]string{"First": "Second"},
+			},
+				Arg{Name: "NAME", Description: "the name", Required: true},
+				Arg{Name: "FILE", Variadic: true, Values: []string{"a.txt", "b.txt"}},
+			)},
+			want: withDefaults(&Command{
+				Name:     "sample",
+				FullPath: "sample",
+				Usage:    "sample NAME [FILE...]",
+				Args: []Arg{
+					{Name: "NAME", Description: "the name", Required: true},
+					{Name: "FILE", Variadic: true, Values: []string{"a.txt", "b.txt"}},
+				},
+				Annotations: map[string]string{
+					"First": "Second",
+				},
+			}),
+		},
+		{
+			name: "Command with invalid args annotation",
+			args: args{cmd: &cobra.Command{
+				Use:         "sample",
+				Annotations: map[string]string{ArgsAnnotation: "NAME"},
+			}},
+			want: withDefaults(&Command{
+				Name:     "sample",
+				FullPath: "sample",
+				Usage:    "sample",
+			}),
+		},
+		{
+			name: "Command with partially decoded args annotation",
+			args: args{cmd: &cobra.Command{
+				Use:         "sample",
+				Annotations: map[string]string{ArgsAnnotation: `[{"name":"NAME"},{"name":1}]`},
+			}},
+			want: withDefaults(&Command{
+				Name:     "sample",
+				FullPath: "sample",
+				Usage:    "sample",
+			}),
+		},
 	}
 	opts := NewOptions()
 	for _, tt := range tests {
@@ -183,3 +283,197 @@ func TestNewCommandFromCobra(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCommandFromCobra_inheritedFlagsUsage(t *testing.T) {
+	root := &cobra.Command{Use: "pinky"}
+	root.PersistentFlags().Bool("verbose", false, "verbose")
+	child := &cobra.Command{Use: "brain", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(child)
+
+	// cobra's help prints [flags] for commands with only inherited flags, once they're merged into the command's flags
+	got := NewCommandFromCobra(child, NewOptions())
+	if want := "pinky brain [flags]"; got.Usage != want {
+		t.Errorf("NewCommandFromCobra() Usage = %q, want %q", got.Usage, want)
+	}
+}
+
+func TestNewCommandFromCobra_flagGroups(t *testing.T) {
+	command := &cobra.Command{Use: "pinky"}
+	command.PersistentFlags().String("user", "", "user")
+	command.Flags().Bool("json", false, "json output")
+	command.Flags().Bool("yaml", false, "yaml output")
+	command.Flags().String("password", "", "password")
+	command.MarkFlagsMutuallyExclusive("json", "yaml")
+	command.MarkFlagsRequiredTogether("user", "password")
+	// equivalent to MarkFlagsOneRequired, available from cobra 1.8
+	for _, name := range []string{"json", "yaml"} {
+		_ = command.Flags().SetAnnotation(name, "cobra_annotation_one_required", []string{"json yaml"})
+	}
+	// groups with undefined flags are ignored, as in cobra
+	_ = command.Flags().SetAnnotation("password", "cobra_annotation_mutually_exclusive", []string{"password token"})
+
+	want := []FlagGroup{
+		{Kind: FlagGroupMutuallyExclusive, Flags: []string{"json", "yaml"}},
+		{Kind: FlagGroupRequiredTogether, Flags: []string{"user", "password"}},
+		{Kind: FlagGroupOneRequired, Flags: []string{"json", "yaml"}},
+	}
+
+	got := NewCommandFromCobra(command, NewOptions())
+	if diff := deep.Equal(got.FlagGroups, want); diff != nil {
+		t.Errorf("NewCommandFromCobra() FlagGroups:\n%v", strings.Join(diff, "\t\n"))
+	}
+}
+
+func TestCommand_GroupedSubcommands(t *testing.T) {
+	newChild := func(use string, groupID string, hidden bool) *cobra.Command {
+		return &cobra.Command{Use: use, GroupID: groupID, Hidden: hidden, Run: func(*cobra.Command, []string) {}}
+	}
+
+	type group struct {
+		title    string
+		commands []string
+	}
+
+	tests := []struct {
+		name   string
+		groups []*cobra.Group
+		args   []*cobra.Command
+		want   []group
+	}{
+		{
+			name: "no subcommands",
+			want: []group{},
+		},
+		{
+			name: "no groups lists visible commands in a single untitled group",
+			args: []*cobra.Command{newChild("alpha", "", false), newChild("beta", "", true)},
+			want: []group{{commands: []string{"alpha"}}},
+		},
+		{
+			name: "groups in declared order with ungrouped commands last",
+			groups: []*cobra.Group{
+				{ID: "mgmt", Title: "Management Commands:"},
+				{ID: "empty", Title: "Empty Commands:"},
+				{ID: "plugin", Title: "Plugin Commands"},
+			},
+			args: []*cobra.Command{
+				newChild("alpha", "plugin", false),
+				newChild("beta", "mgmt", false),
+				newChild("gamma", "", false),
+				newChild("delta", "mgmt", true),
+			},
+			want: []group{
+				{title: "Management Commands", commands: []string{"beta"}},
+				{title: "Plugin Commands", commands: []string{"alpha"}},
+				{title: "Additional Commands", commands: []string{"gamma"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "root"}
+			root.AddGroup(tt.groups...)
+			root.AddCommand(tt.args...)
+
+			cmd := NewCommandFromCobra(root, NewOptions())
+			if len(cmd.Groups) != len(tt.groups) {
+				t.Errorf("NewCommandFromCobra() Groups = %v, want %d groups", cmd.Groups, len(tt.groups))
+			}
+
+			got := make([]group, 0)
+			for _, g := range cmd.GroupedSubcommands() {
+				names := make([]string, 0)
+				for _, c := range g.Commands {
+					names = append(names, c.Name)
+				}
+				got = append(got, group{title: g.Title, commands: names})
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("GroupedSubcommands():\n%v", strings.Join(diff, "\t\n"))
+			}
+		})
+	}
+}
+
+func TestDocumentation_init(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *Options
+		epoch   string
+		want    string
+	}{
+		{
+			name:    "explicit date with default layout",
+			options: NewOptions().WithGenerationDate(time.Date(2023, time.March, 4, 5, 6, 7, 0, time.UTC)),
+			epoch:   "1700000000",
+			want:    "4-Mar-2023",
+		},
+		{
+			name:    "explicit date with custom layout",
+			options: NewOptions().WithGenerationDate(time.Date(2023, time.March, 4, 5, 6, 7, 0, time.UTC)).WithDateLayout("2006-01-02"),
+			want:    "2023-03-04",
+		},
+		{
+			name:    "SOURCE_DATE_EPOCH",
+			options: NewOptions().WithDateLayout(time.RFC3339),
+			epoch:   "1700000000",
+			want:    "2023-11-14T22:13:20Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(sourceDateEpochEnv, tt.epoch)
+			doc := Documentation{options: tt.options}
+			doc.init()
+			if doc.GenerationDate != tt.want {
+				t.Errorf("init() GenerationDate = %v, want %v", doc.GenerationDate, tt.want)
+			}
+		})
+	}
+
+	t.Run("existing date is retained", func(t *testing.T) {
+		doc := Documentation{GenerationDate: "retained", options: NewOptions()}
+		doc.init()
+		if doc.GenerationDate != "retained" {
+			t.Errorf("init() GenerationDate = %v, want retained", doc.GenerationDate)
+		}
+	})
+}
+
+func TestRender_reproducible(t *testing.T) {
+	t.Setenv(sourceDateEpochEnv, "1700000000")
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{
+			Use:         "simple",
+			Short:       "s",
+			Annotations: map[string]string{"zeta": "z", "alpha": "a", "mu": "m", "beta": "b"},
+		}
+		root.AddCommand(&cobra.Command{Use: "child", Annotations: map[string]string{"b": "2", "a": "1"}})
+		return root
+	}
+
+	opts := NewOptions().WithFormats(Markdown | Yaml | Json | ReST | Man)
+	first, err := Render(NewDocumentation(newRoot(), opts))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	second, err := Render(NewDocumentation(newRoot(), opts))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if diff := deep.Equal(first, second); diff != nil {
+		t.Errorf("Render() not reproducible:\n%v", strings.Join(diff, "\t\n"))
+	}
+
+	json := string(first["simple/simple.json"])
+	if !strings.Contains(json, "\n  \"generationDate\": \"14-Nov-2023\",\n") {
+		t.Errorf("Render() expected indented json with pinned date, got:\n%s", json)
+	}
+	for _, output := range []string{json, string(first["simple/simple.yml"])} {
+		alpha, beta, mu, zeta := strings.Index(output, "alpha"), strings.Index(output, "beta"), strings.Index(output, "mu"), strings.Index(output, "zeta")
+		if !(alpha < beta && beta < mu && mu < zeta) {
+			t.Errorf("Render() expected sorted annotations, got:\n%s", output)
+		}
+	}
+}
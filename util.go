@@ -13,6 +13,15 @@ func maxInt(x, y int) int {
 	return x
 }
 
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
 func hangingIndent(input string, hangWidth int, maxWidth int) string {
 	if len(input) == maxWidth {
 		return input
@@ -70,3 +79,60 @@ func hangingIndent(input string, hangWidth int, maxWidth int) string {
 	}
 	return buf.String()
 }
+
+// commandPosition is the 1-based position of c among the visible subcommands of its parent within root, or 1 for root
+func commandPosition(root Command, c Command) int {
+	if c.Parent == nil {
+		return 1
+	}
+
+	var find func(parent Command) int
+	find = func(parent Command) int {
+		if parent.FullPath == c.Parent.FullPath {
+			position := 0
+			for _, sub := range parent.Subcommands {
+				if !sub.Hidden {
+					position++
+				}
+				if sub.FullPath == c.FullPath {
+					return position
+				}
+			}
+		}
+		for _, sub := range parent.Subcommands {
+			if position := find(sub); position > 0 {
+				return position
+			}
+		}
+		return 0
+	}
+	return find(root)
+}
+
+// sectionedCommand is a command within a single-document format, along with the sectioning command for its depth
+type sectionedCommand struct {
+	Command
+	// Sectioning is the format's sectioning command for the depth of the command, e.g. chapter or section
+	Sectioning string
+}
+
+// sectionCommands flattens the tree of visible commands under c in depth-first order, following the order of
+// GroupedSubcommands. Commands nested deeper than the available sectioning commands reuse the last one.
+func sectionCommands(c Command, sectioning []string) []sectionedCommand {
+	result := make([]sectionedCommand, 0)
+	var walk func(c Command, depth int)
+	walk = func(c Command, depth int) {
+		current := sectioning[len(sectioning)-1]
+		if depth < len(sectioning) {
+			current = sectioning[depth]
+		}
+		result = append(result, sectionedCommand{Command: c, Sectioning: current})
+		for _, group := range c.GroupedSubcommands() {
+			for _, sub := range group.Commands {
+				walk(sub, depth+1)
+			}
+		}
+	}
+	walk(c, 0)
+	return result
+}
@@ -7,14 +7,11 @@ import (
 	"strings"
 )
 
-type writerFn func() writer
-
-var writers = make(map[Formats]writerFn)
-
 type docCommandOptions struct {
 	outDir     string
 	formats    []string
 	showHidden bool
+	check      bool
 }
 
 // Initialize a new documentation command with cmd as the parent, providing options for customization
@@ -29,21 +26,8 @@ func Initialize(cmd *cobra.Command, options *Options) error {
 		return err
 	}
 
-	formats := make([]string, 0)
-	for _, format := range options.formats.defined() {
-		switch format {
-		case Markdown:
-			formats = append(formats, "markdown")
-		case Yaml:
-			formats = append(formats, "yaml")
-		case Json:
-			formats = append(formats, "json")
-		case ReST:
-			formats = append(formats, "rest")
-		case Man:
-			// ignore
-		}
-	}
+	formats := make([]string, len(options.formats))
+	copy(formats, options.formats)
 
 	o := docCommandOptions{
 		outDir:     options.outDir,
@@ -65,13 +49,28 @@ func Initialize(cmd *cobra.Command, options *Options) error {
 
 			definedFormats := getUserSelectedFormats(o, opts)
 
-			if !definedFormats.IsValid() {
+			if len(definedFormats) == 0 {
 				return errors.New("invalid formats selected")
 			}
 
 			opts.formats = definedFormats
 
 			documentation := NewDocumentation(root, &opts)
+			if o.check {
+				result, err := Check(documentation)
+				if err != nil {
+					return err
+				}
+				if !result.IsCurrent() {
+					// the error is expected to fail a build, where usage text only adds noise
+					cmd.SilenceUsage = true
+					_, _ = fmt.Fprint(cmd.ErrOrStderr(), result.String())
+					return ErrDocumentationOutdated
+				}
+				opts.templateOptions.Logger.Printf("Documentation in %s is up to date", opts.outDir)
+				return nil
+			}
+
 			if err := Write(documentation); err != nil {
 				return err
 			}
@@ -85,6 +84,7 @@ func Initialize(cmd *cobra.Command, options *Options) error {
 		docCommand.Flags().BoolVar(&o.showHidden, "show-hidden", o.showHidden, "Also show hidden commands")
 		docCommand.Flags().StringSliceVar(&o.formats, "formats", o.formats,
 			fmt.Sprintf("A comma-separated list of formats to output. Allowed: [%s]", strings.Join(formats, ",")))
+		docCommand.Flags().BoolVar(&o.check, "check", false, "Check that documentation in the output directory is up to date, without writing")
 	}
 
 	cmd.AddCommand(docCommand)
@@ -92,75 +92,87 @@ func Initialize(cmd *cobra.Command, options *Options) error {
 	return nil
 }
 
-func getUserSelectedFormats(o docCommandOptions, opts Options) Formats {
-	definedFormats := Formats(0)
+func getUserSelectedFormats(o docCommandOptions, opts Options) []string {
+	definedFormats := make([]string, 0)
 	for _, format := range o.formats {
-		switch strings.ToLower(format) {
-		case "markdown", "md":
-			if opts.formats.IsSet(Markdown) {
-				definedFormats.Set(Markdown)
-			} else {
-				opts.templateOptions.Logger.Printf("Skipping markdown documentation because the application maintainers have not enabled this output format.")
-			}
-		case "yaml", "yml":
-			if opts.formats.IsSet(Yaml) {
-				definedFormats.Set(Yaml)
-			} else {
-				opts.templateOptions.Logger.Printf("Skipping yaml documentation because the application maintainers have not enabled this output format.")
-			}
-		case "json":
-			if opts.formats.IsSet(Json) {
-				definedFormats.Set(Json)
-			} else {
-				opts.templateOptions.Logger.Printf("Skipping json documentation because the application maintainers have not enabled this output format.")
-			}
-		case "rest", "rst":
-			if opts.formats.IsSet(ReST) {
-				definedFormats.Set(ReST)
-			} else {
-				opts.templateOptions.Logger.Printf("Skipping rest documentation because the application maintainers have not enabled this output format.")
-			}
-		default:
+		definition, ok := LookupFormat(format)
+		switch {
+		case !ok:
 			opts.templateOptions.Logger.Printf("Skipping %s documentation because it is not currently supported.", format)
+		case !opts.hasFormat(definition.Name):
+			opts.templateOptions.Logger.Printf("Skipping %s documentation because the application maintainers have not enabled this output format.", definition.Name)
+		case !contains(definedFormats, definition.Name):
+			definedFormats = append(definedFormats, definition.Name)
 		}
 	}
 	return definedFormats
 }
 
-// registerWriter for a given format to allow writing via the writer function
-func registerWriter(format Formats, writer writerFn) {
-	writers[format] = writer
+// Write to outDir the documentation for all given formats. Files are only written when their contents change, and
+// files from a previous Write which are no longer generated are removed (see ManifestName).
+func Write(documentation Documentation) error {
+	options := documentation.options
+	out := NewDirectoryOutput(options.outDir)
+	if options.fileMode != 0 {
+		out.FileMode = options.fileMode
+	}
+	if options.directoryMode != 0 {
+		out.DirectoryMode = options.directoryMode
+	}
+
+	manifest, err := newManifestOutput(out, options.templateOptions.Logger)
+	if err != nil {
+		return err
+	}
+	if err = WriteTo(documentation, manifest); err != nil {
+		return err
+	}
+	return manifest.finish(options.formats)
 }
 
-// Write to outDir the documentation for all given formats
-func Write(documentation Documentation) error {
+// Render the documentation for all given formats in memory, keyed by the slash-separated path relative to outDir
+func Render(documentation Documentation) (map[string][]byte, error) {
+	out := NewMemoryOutput()
+	if err := WriteTo(documentation, out); err != nil {
+		return nil, err
+	}
+	return out.Files(), nil
+}
+
+// WriteTo writes the documentation for all given formats to out
+func WriteTo(documentation Documentation, out Output) error {
 	var err error
 	options := documentation.options
 	formats := options.formats
-	outDir := options.outDir
 
-	if !formats.IsValid() {
+	if len(formats) == 0 {
 		return errors.New("unexpected formats provided to Write")
 	}
 
+	for _, format := range formats {
+		if _, ok := LookupFormat(format); !ok {
+			return fmt.Errorf("missing output writer for format %v", format)
+		}
+	}
+
 	// ensure proper initialization
 	documentation.init()
 
 	templateOptions := options.TemplateOptions()
-	for _, format := range []Formats{Yaml, Json, Markdown, Man, ReST} {
-		if formats.IsSet(format) {
-			templateOptions.Logger.Printf("Generating documentation for %s", strings.ToLower(format.String()))
-			if writeBuilder, ok := writers[format]; ok {
-				w := writeBuilder()
-				if wt, ok := w.(wantsTemplateOptions); ok {
-					wt.SetTemplateOptions(templateOptions)
-				}
-				err = w.Write(outDir, documentation)
-				if err != nil {
-					return err
-				}
-			} else {
-				return fmt.Errorf("missing output writer for format %v", format)
+	for _, definition := range RegisteredFormats() {
+		if options.hasFormat(definition.Name) {
+			templateOptions.Logger.Printf("Generating documentation for %s", definition.Name)
+			w := definition.writer()
+			if wt, ok := w.(WantsTemplateOptions); ok {
+				wt.SetTemplateOptions(templateOptions)
+			}
+			target := out
+			if scoped, ok := out.(formatScopedOutput); ok {
+				target = scoped.forFormat(definition.Name)
+			}
+			err = w.Write(target, documentation)
+			if err != nil {
+				return err
 			}
 		}
 	}
@@ -1,9 +1,14 @@
 package venom
 
-type writer interface {
-	Write(outDir string, doc Documentation) error
+// Writer outputs documentation for a single format to out
+type Writer interface {
+	Write(out Output, doc Documentation) error
 }
 
-type wantsTemplateOptions interface {
+// WantsTemplateOptions may be implemented by a Writer which requires the current TemplateOptions before writing
+type WantsTemplateOptions interface {
 	SetTemplateOptions(options TemplateOptions)
 }
+
+// WriterFn constructs a new Writer. A new Writer is constructed for every call to Write.
+type WriterFn func() Writer
@@ -0,0 +1,154 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// asciiDocFormat is the name of the format which writes AsciiDoc, optionally laid out as an Antora module
+const asciiDocFormat = "AsciiDoc"
+
+// antoraModuleDirectory is the Antora module to which pages are written when AntoraLayout is enabled
+const antoraModuleDirectory = "modules/ROOT"
+
+// asciidocNavItem is a single entry of the Antora navigation list
+type asciidocNavItem struct {
+	// Depth of the command in the command tree, where the root command is 1
+	Depth    int
+	FullPath string
+}
+
+type functionsAsciiDoc struct {
+}
+
+func (f functionsAsciiDoc) FormatHeader(input string) string {
+	return input
+}
+
+func (f functionsAsciiDoc) FormatText(input string) string {
+	return stripansi.String(input)
+}
+
+func (f functionsAsciiDoc) FormatOptions(input string) string {
+	return trimIndent(stripansi.String(input), 2)
+}
+
+// FormatFlag returns a description list entry for the flag, with its signature as the term
+func (f functionsAsciiDoc) FormatFlag(input Flag) string {
+	if input.Hidden {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString("`+")
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString(fmt.Sprintf("-%s, ", input.Shorthand))
+	}
+	buf.WriteString("--" + input.Name)
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(" " + input.Type)
+	}
+	buf.WriteString("+`")
+	if input.Required {
+		buf.WriteString(" (required)")
+	}
+	buf.WriteString("::\n")
+	buf.WriteString(f.FormatText(input.Usage))
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			defaultValue = fmt.Sprintf("%q", defaultValue)
+		}
+		buf.WriteString(fmt.Sprintf(" (default `+%s+`)", defaultValue))
+	}
+	if input.Deprecated != "" {
+		buf.WriteString(fmt.Sprintf(" (DEPRECATED: %s)", f.FormatText(input.Deprecated)))
+	}
+	return buf.String()
+}
+
+// SeeAlsoPath returns an xref macro to the page of the command at input
+func (f functionsAsciiDoc) SeeAlsoPath(input string) string {
+	return fmt.Sprintf("xref:%s.adoc[%s]", internal.CleanPath(input), input)
+}
+
+func (f functionsAsciiDoc) FormatExample(input string) string {
+	// markdown code fences are replaced by an AsciiDoc listing block
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return fmt.Sprintf("[source,shell]\n----\n%s\n----", trimIndent(stripansi.String(replaced), -1))
+}
+
+func (f functionsAsciiDoc) FormatAutoGenTag(input string) string {
+	return input
+}
+
+func (f functionsAsciiDoc) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides asciidoc-specific template functions
+func (f functionsAsciiDoc) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"nav": f.nav,
+	}
+}
+
+// nav flattens the tree of visible commands under c, in depth-first order
+func (f functionsAsciiDoc) nav(c Command) []asciidocNavItem {
+	items := make([]asciidocNavItem, 0)
+	var walk func(c Command, depth int)
+	walk = func(c Command, depth int) {
+		items = append(items, asciidocNavItem{Depth: depth, FullPath: c.FullPath})
+		for _, sub := range c.Subcommands {
+			if !sub.Hidden {
+				walk(sub, depth+1)
+			}
+		}
+	}
+	walk(c, 1)
+	return items
+}
+
+type writerAsciiDoc struct {
+	options TemplateOptions
+}
+
+func (w *writerAsciiDoc) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:          asciiDocFormat,
+		fileExtension: "adoc",
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         functionsAsciiDoc{},
+		includeIndex:  true,
+	}
+
+	if w.options.AntoraLayout {
+		helper.pagesDirectory = path.Join(antoraModuleDirectory, "pages")
+		helper.assets = []templateAsset{
+			{target: "nav", name: path.Join(antoraModuleDirectory, "nav.adoc")},
+		}
+	}
+
+	return helper.write()
+}
+
+func (w *writerAsciiDoc) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(asciiDocFormat, []string{"adoc"}, "adoc", func() Writer {
+		return &writerAsciiDoc{}
+	})
+}
+
+var (
+	_ functions         = (*functionsAsciiDoc)(nil)
+	_ extendedFunctions = (*functionsAsciiDoc)(nil)
+)
@@ -0,0 +1,128 @@
+package venom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAsciiDocWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc asciidoc",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s",
+			Long:     "simple doc asciidoc",
+			Args: []Arg{
+				{Name: "FILE", Variadic: true, Values: []string{"a", "b"}},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an output flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:       "command",
+					FullPath:   "simple command",
+					Usage:      "simple command",
+					Short:      "c",
+					Deprecated: "use another",
+					Parent:     &ParentCommand{Name: "simple", Short: "s", FullPath: "simple"},
+					Examples:   []string{"```\nsimple command --testing\n```"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name: "writes asciidoc pages",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple/simple.adoc": {
+					"= simple\n\ns\n\n== Synopsis\n\nsimple doc asciidoc\n",
+					"[source,shell]\n----\nsimple [flags]\n----\n",
+					"== Arguments\n\n`+FILE...+`::\nAllowed values: a, b\n",
+					"== Options\n\n`+-t, --testing+`::\na test flag\n`+--output string+` (required)::\nan output flag (default `+\"json\"+`)\n",
+					"== See also\n\n* xref:simple_command.adoc[simple command] - c\n",
+					"_generated by: Simple doc asciidoc 1-Jan-2023_",
+				},
+				"simple/simple_command.adoc": {
+					"= simple command\n\nWARNING: Deprecated: use another\n",
+					"== Examples\n\n[source,shell]\n----\nsimple command --testing\n----\n",
+					"* xref:simple.adoc[simple] - s\n",
+				},
+				"simple/index.adoc": {
+					"* xref:simple.adoc[simple] - s\n* xref:simple_command.adoc[simple command] - c\n",
+				},
+			},
+			missing: []string{"simple/modules/ROOT/nav.adoc"},
+		},
+		{
+			name: "writes antora module",
+			args: args{options: NewOptions().WithAntoraLayout().TemplateOptions()},
+			files: map[string][]string{
+				"simple/modules/ROOT/pages/simple.adoc":         {"= simple\n"},
+				"simple/modules/ROOT/pages/simple_command.adoc": {"= simple command\n"},
+				"simple/modules/ROOT/pages/index.adoc":          {"= simple\n"},
+				"simple/modules/ROOT/nav.adoc": {
+					"* xref:index.adoc[simple]\n** xref:simple.adoc[simple]\n*** xref:simple_command.adoc[simple command]\n",
+				},
+			},
+			missing: []string{"simple/simple.adoc", "simple/index.adoc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerAsciiDoc{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerAsciiDoc() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files[name]
+				if !ok {
+					t.Fatalf("writerAsciiDoc() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerAsciiDoc() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files[name]; ok {
+					t.Errorf("writerAsciiDoc() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package venom
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// carapaceFormat is the name of the format which writes a carapace spec
+const carapaceFormat = "Carapace"
+
+// carapaceSchema associates the spec with its JSON schema, for validation in editors
+const carapaceSchema = "# yaml-language-server: $schema=https://carapace.sh/schemas/command.json\n"
+
+// carapaceCommand is a command of a carapace spec, see https://carapace-sh.github.io/carapace-spec/carapace-spec/command.html
+type carapaceCommand struct {
+	Name            string              `yaml:"name"`
+	Aliases         carapaceValues      `yaml:"aliases,omitempty"`
+	Description     string              `yaml:"description,omitempty"`
+	Group           string              `yaml:"group,omitempty"`
+	Hidden          bool                `yaml:"hidden,omitempty"`
+	Flags           carapaceFlags       `yaml:"flags,omitempty"`
+	PersistentFlags carapaceFlags       `yaml:"persistentflags,omitempty"`
+	Completion      *carapaceCompletion `yaml:"completion,omitempty"`
+	Commands        []carapaceCommand   `yaml:"commands,omitempty"`
+}
+
+type carapaceCompletion struct {
+	Positional    []carapaceValues `yaml:"positional,omitempty"`
+	PositionalAny carapaceValues   `yaml:"positionalany,omitempty"`
+}
+
+// carapaceValues are written in flow style, as in carapace's examples
+type carapaceValues []string
+
+func (v carapaceValues) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{}
+	if err := node.Encode([]string(v)); err != nil {
+		return nil, err
+	}
+	node.Style = yaml.FlowStyle
+	return node, nil
+}
+
+type carapaceFlag struct {
+	name        string
+	description string
+}
+
+// carapaceFlags maps the name of each flag to its description, in the order of the command's flags
+type carapaceFlags []carapaceFlag
+
+func (f carapaceFlags) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, flag := range f {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: flag.name},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: flag.description},
+		)
+	}
+	return node, nil
+}
+
+func newCarapaceCommand(c Command) carapaceCommand {
+	result := carapaceCommand{
+		Name:        c.Name,
+		Aliases:     c.Aliases,
+		Description: c.Short,
+		Group:       c.GroupID,
+		// cobra doesn't complete deprecated commands either
+		Hidden: c.Hidden || c.Deprecated != "",
+	}
+
+	// inherited flags are omitted, as they're persistent flags of an ancestor
+	persistent := make(map[string]bool)
+	for _, flag := range c.PersistentFlags {
+		persistent[flag.Name] = true
+		result.PersistentFlags = append(result.PersistentFlags, newCarapaceFlag(flag))
+	}
+	for _, flag := range c.LocalFlags {
+		if !persistent[flag.Name] {
+			result.Flags = append(result.Flags, newCarapaceFlag(flag))
+		}
+	}
+
+	completion := carapaceCompletion{}
+	hasValues := false
+	for _, arg := range c.Args {
+		hasValues = hasValues || len(arg.Values) > 0
+		if arg.Variadic {
+			completion.PositionalAny = arg.Values
+		} else {
+			completion.Positional = append(completion.Positional, append(carapaceValues{}, arg.Values...))
+		}
+	}
+	if len(c.Args) == 0 && len(c.ValidArgs) > 0 {
+		hasValues = true
+		completion.Positional = []carapaceValues{c.ValidArgs}
+	}
+	if hasValues {
+		result.Completion = &completion
+	}
+
+	for _, sub := range c.Subcommands {
+		result.Commands = append(result.Commands, newCarapaceCommand(sub))
+	}
+	return result
+}
+
+// newCarapaceFlag names the flag with carapace's syntax, as in "-s, --long=", where the suffix describes the flag's
+// value along with whether it's repeatable, required, or hidden
+func newCarapaceFlag(flag Flag) carapaceFlag {
+	name := "--" + flag.Name
+	if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+		name = fmt.Sprintf("-%s, %s", flag.Shorthand, name)
+	}
+
+	switch {
+	case flag.Type == "bool" || flag.Type == "count":
+	case flag.NoOptDefVal != "":
+		name += "?"
+	default:
+		name += "="
+	}
+	// slices accumulate values, and counts are incremented on each occurrence
+	if flag.Type == "count" || strings.HasSuffix(flag.Type, "Slice") || strings.HasSuffix(flag.Type, "Array") {
+		name += "*"
+	}
+	if flag.Required {
+		name += "!"
+	}
+	if flag.Hidden {
+		name += "&"
+	}
+
+	_, usage := unquoteUsage(flag)
+	return carapaceFlag{name: name, description: usage}
+}
+
+type writerCarapace struct {
+	options TemplateOptions
+}
+
+func (w *writerCarapace) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func (w *writerCarapace) Write(out Output, doc Documentation) error {
+	helper := writerForMarshals{
+		name:          carapaceFormat,
+		fileExtension: "yaml",
+		out:           out,
+		doc:           doc,
+		marshaller:    w.marshal,
+		logger:        w.options.Logger,
+	}
+	return helper.write()
+}
+
+// marshal encodes the documentation as a carapace spec
+func (w *writerCarapace) marshal(v interface{}) ([]byte, error) {
+	doc := v.(*Documentation)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(carapaceSchema)
+	if doc.AutoGenerationTag != "" {
+		buf.WriteString(fmt.Sprintf("# %s %s\n", doc.AutoGenerationTag, doc.GenerationDate))
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(newCarapaceCommand(doc.RootCommand)); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func init() {
+	mustRegisterFormat(carapaceFormat, []string{"carapace-spec"}, "yaml", func() Writer {
+		return &writerCarapace{}
+	})
+}
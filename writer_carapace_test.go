@@ -0,0 +1,54 @@
+package venom
+
+import (
+	"testing"
+)
+
+func TestCarapaceWrite(t *testing.T) {
+	doc := newSimpleTestDocumentation("carapace")
+	doc.RootCommand.Subcommands[2].GroupID = "main"
+
+	want := `# yaml-language-server: $schema=https://carapace.sh/schemas/command.json
+# generated by: Simple doc carapace 1-Jan-2023
+name: simple
+description: s <s>
+flags:
+  -v, --verbose*: verbosity
+  --secret&: a hidden flag
+persistentflags:
+  -c, --config=: the file to read
+commands:
+  - name: command
+    aliases: [cmd]
+    description: c
+    flags:
+      --output?!: output format
+      --tags=*&: tags
+    completion:
+      positional:
+        - [x, "y"]
+  - name: hidden
+    description: h
+    hidden: true
+    completion:
+      positional:
+        - []
+      positionalany: [a, b]
+  - name: single
+    group: main
+`
+
+	out := NewMemoryOutput()
+	w := writerCarapace{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerCarapace() error = %v", err)
+	}
+
+	got, ok := out.Files()["simple/simple.yaml"]
+	if !ok {
+		t.Fatalf("writerCarapace() missing file simple.yaml in %v", out.Names())
+	}
+	if string(got) != want {
+		t.Errorf("writerCarapace() got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,23 +1,74 @@
 package venom
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"github.com/jimschubert/venom/internal"
-	"os"
-	"path/filepath"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
 	"strings"
 	"text/template"
 	"unicode"
 )
 
+// templateSet abstracts over text/template and html/template, which share behavior but not types
+type templateSet interface {
+	ExecuteTemplate(wr io.Writer, name string, data interface{}) error
+	defines(name string) bool
+}
+
+type textTemplateSet struct {
+	*template.Template
+}
+
+func (t textTemplateSet) defines(name string) bool {
+	return t.Lookup(name) != nil
+}
+
+type htmlTemplateSet struct {
+	*htmltemplate.Template
+}
+
+func (t htmlTemplateSet) defines(name string) bool {
+	return t.Lookup(name) != nil
+}
+
+// templateAsset is a supporting file rendered once per documentation root, such as a stylesheet
+type templateAsset struct {
+	// target is the template target, as passed to filenameFor
+	target string
+	// name of the output file, relative to the documentation root
+	name string
+}
+
 type writerForTemplates struct {
 	name          string
 	fileExtension string
-	outDir        string
+	out           Output
 	doc           Documentation
 	options       TemplateOptions
 	funcs         functions
 	includeIndex  bool
+	// pathSeparator replaces invalid path characters (including spaces between command names) in output file names; defaults to underscore
+	pathSeparator string
+	// compress output files with gzip, appending .gz to each file name
+	compress bool
+	// escapeHTML parses templates with html/template, contextually escaping all template output
+	escapeHTML bool
+	// pagePath maps a command's full path to its page, without extension, relative to pagesDirectory; defaults to cleanPath
+	pagePath func(fullPath string) string
+	// indexName is the name of the index page without extension; defaults to index, or README if the root command is index
+	indexName string
+	// pagesDirectory is the directory, relative to the documentation root, to which command and index pages are written
+	pagesDirectory string
+	// assets are written once after all commands, and skipped if the user's templates don't define them
+	assets []templateAsset
+	// singleDocument skips command pages, for formats which render the whole command tree into one asset
+	singleDocument bool
 }
 
 func (w *writerForTemplates) filenameFor(target string) string {
@@ -25,56 +76,133 @@ func (w *writerForTemplates) filenameFor(target string) string {
 	return fmt.Sprintf("%s_%s.tmpl", templateName, strings.ToLower(target))
 }
 
+func (w *writerForTemplates) pageName(fullPath string) string {
+	if w.pagePath != nil {
+		return w.pagePath(fullPath)
+	}
+	return w.cleanPath(fullPath)
+}
+
+func (w *writerForTemplates) cleanPath(input string) string {
+	if w.pathSeparator == "" {
+		return internal.CleanPath(input)
+	}
+	return internal.CleanPath(input, w.pathSeparator)
+}
+
+// execute the named template into the file at name, which differs in the return value when compressing
+func (w *writerForTemplates) execute(t templateSet, templateName string, name string, data interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	if err := t.ExecuteTemplate(&buf, templateName, data); err != nil {
+		return name, err
+	}
+
+	contents := buf.Bytes()
+	if w.compress {
+		name = name + ".gz"
+		compressed := bytes.Buffer{}
+		gz := gzip.NewWriter(&compressed)
+		if _, err := gz.Write(contents); err != nil {
+			return name, err
+		}
+		if err := gz.Close(); err != nil {
+			return name, err
+		}
+		contents = compressed.Bytes()
+	}
+
+	return name, w.out.WriteFile(name, contents)
+}
+
 func (w *writerForTemplates) write() error {
-	t, err := template.New(w.name).Funcs(newFuncMap(w.funcs)).ParseFS(w.options.Templates, "**/*.tmpl")
+	matches, err := fs.Glob(w.options.Templates, "**/*.tmpl")
 	if err != nil {
 		return err
 	}
+	// templates are selected by name, and other templates may be shared with this writer, such as partials
+	prefix := strings.ToLower(w.name) + "_"
+	own := make([]string, 0, len(matches))
+	shared := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if strings.HasPrefix(path.Base(match), prefix) {
+			own = append(own, match)
+		} else {
+			shared = append(shared, match)
+		}
+	}
+	if len(own) == 0 {
+		w.options.Logger.Printf("[%s] Skipping: no templates found matching %q", w.name, "**/"+prefix+"*.tmpl")
+		return nil
+	}
 
-	docRoot := filepath.Join(w.outDir, internal.CleanPath(w.doc.RootCommand.Name))
-
-	if err = w.writeCommands(t, docRoot); err != nil {
+	t, err := w.parse(shared, own)
+	if err != nil {
 		return err
 	}
 
+	docRoot := internal.CleanPath(w.doc.RootCommand.Name)
+
+	if !w.singleDocument {
+		if err = w.writeCommands(t, docRoot); err != nil {
+			return err
+		}
+	}
+
 	if w.includeIndex {
 		if err = w.writeIndex(t, docRoot); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return w.writeAssets(t, docRoot)
 }
 
-func (w *writerForTemplates) writeCommands(t *template.Template, docRoot string) error {
-	commandTemplateName := w.filenameFor("command")
-	if t.Lookup(commandTemplateName) != nil {
-		if err := os.MkdirAll(docRoot, 0700); err != nil {
-			return err
+// undefinedTemplateFunction matches the error of parsing a template which calls a function that isn't defined
+var undefinedTemplateFunction = regexp.MustCompile(`function "[^"]*" not defined`)
+
+// parse the templates of this writer, along with the shared templates. Shared templates of other formats which call
+// functions not available here are skipped, while any other error fails the parse. This writer's templates are parsed
+// last so their definitions take precedence.
+func (w *writerForTemplates) parse(shared []string, own []string) (templateSet, error) {
+	funcMap := newFuncMap(w.funcs)
+	matches := make([]string, 0, len(shared)+len(own))
+	for _, match := range shared {
+		_, err := template.New(w.name).Funcs(funcMap).ParseFS(w.options.Templates, match)
+		switch {
+		case err == nil:
+			matches = append(matches, match)
+		case !undefinedTemplateFunction.MatchString(err.Error()):
+			return nil, err
 		}
+	}
+	matches = append(matches, own...)
+
+	if w.escapeHTML {
+		t, err := htmltemplate.New(w.name).Funcs(htmltemplate.FuncMap(funcMap)).ParseFS(w.options.Templates, matches...)
+		return htmlTemplateSet{t}, err
+	}
+	t, err := template.New(w.name).Funcs(funcMap).ParseFS(w.options.Templates, matches...)
+	return textTemplateSet{t}, err
+}
 
+func (w *writerForTemplates) writeCommands(t templateSet, docRoot string) error {
+	commandTemplateName := w.filenameFor("command")
+	if t.defines(commandTemplateName) {
 		if err := w.writeRootCommand(t, docRoot); err != nil {
 			return err
 		}
 
-		var writeCommand func(c Command, t *template.Template) error
-		writeCommand = func(c Command, t *template.Template) error {
-			subCommandPath := filepath.Join(docRoot, fmt.Sprintf("%s.%s", internal.CleanPath(c.FullPath), w.fileExtension))
-			subCommandFile, err := os.Create(subCommandPath)
-			if err != nil {
-				return err
-			}
-			defer func(subCommandFile *os.File) {
-				_ = subCommandFile.Close()
-			}(subCommandFile)
-
-			if err := t.ExecuteTemplate(subCommandFile, commandTemplateName, struct {
+		var writeCommand func(c Command, t templateSet) error
+		writeCommand = func(c Command, t templateSet) error {
+			subCommandPath := path.Join(docRoot, w.pagesDirectory, fmt.Sprintf("%s.%s", w.pageName(c.FullPath), w.fileExtension))
+			subCommandPath, err := w.execute(t, commandTemplateName, subCommandPath, struct {
 				Command
 				Doc Documentation
 			}{
 				Command: c,
 				Doc:     w.doc,
-			}); err != nil {
+			})
+			if err != nil {
 				return err
 			}
 
@@ -103,26 +231,17 @@ func (w *writerForTemplates) writeCommands(t *template.Template, docRoot string)
 	return nil
 }
 
-func (w *writerForTemplates) writeRootCommand(t *template.Template, docRoot string) error {
+func (w *writerForTemplates) writeRootCommand(t templateSet, docRoot string) error {
 	commandTemplateName := w.filenameFor("command")
-	rootCommandPath := filepath.Join(docRoot, fmt.Sprintf("%s.%s", internal.CleanPath(w.doc.RootCommand.Name), w.fileExtension))
-	rootCommand, err := os.Create(rootCommandPath)
-
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(rootCommand)
-
-	if err != nil {
-		return err
-	}
-
-	if err = t.ExecuteTemplate(rootCommand, commandTemplateName, struct {
+	rootCommandPath := path.Join(docRoot, w.pagesDirectory, fmt.Sprintf("%s.%s", w.pageName(w.doc.RootCommand.Name), w.fileExtension))
+	rootCommandPath, err := w.execute(t, commandTemplateName, rootCommandPath, struct {
 		Command
 		Doc Documentation
 	}{
 		Command: w.doc.RootCommand,
 		Doc:     w.doc,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
@@ -130,27 +249,20 @@ func (w *writerForTemplates) writeRootCommand(t *template.Template, docRoot stri
 	return nil
 }
 
-func (w *writerForTemplates) writeIndex(t *template.Template, docRoot string) error {
+func (w *writerForTemplates) writeIndex(t templateSet, docRoot string) error {
 	indexTemplateName := w.filenameFor("index")
 	// if the writer supports an index, but user has customized without the targeted index, we just skip and log
-	if t.Lookup(indexTemplateName) != nil {
-		var indexName string
-		if w.doc.RootCommand.Name == "index" {
-			indexName = "README"
-		} else {
-			indexName = "index"
-		}
-		indexPath := filepath.Join(docRoot, fmt.Sprintf("%s.%s", indexName, w.fileExtension))
-		index, err := os.Create(indexPath)
-		defer func(f *os.File) {
-			_ = f.Close()
-		}(index)
-
-		if err != nil {
-			return err
+	if t.defines(indexTemplateName) {
+		indexName := w.indexName
+		if indexName == "" {
+			if w.doc.RootCommand.Name == "index" {
+				indexName = "README"
+			} else {
+				indexName = "index"
+			}
 		}
-
-		err = t.ExecuteTemplate(index, indexTemplateName, w.doc)
+		indexPath := path.Join(docRoot, w.pagesDirectory, fmt.Sprintf("%s.%s", indexName, w.fileExtension))
+		indexPath, err := w.execute(t, indexTemplateName, indexPath, w.doc)
 
 		if err == nil {
 			w.options.Logger.Printf("[%s] Wrote file %s", w.name, indexPath)
@@ -162,10 +274,27 @@ func (w *writerForTemplates) writeIndex(t *template.Template, docRoot string) er
 	return nil
 }
 
+func (w *writerForTemplates) writeAssets(t templateSet, docRoot string) error {
+	for _, asset := range w.assets {
+		assetTemplateName := w.filenameFor(asset.target)
+		if !t.defines(assetTemplateName) {
+			w.options.Logger.Printf("[%s] Skipping %s: no template found for %q", w.name, asset.name, assetTemplateName)
+			continue
+		}
+
+		assetPath, err := w.execute(t, assetTemplateName, path.Join(docRoot, asset.name), w.doc)
+		if err != nil {
+			return err
+		}
+		w.options.Logger.Printf("[%s] Wrote file %s", w.name, assetPath)
+	}
+	return nil
+}
+
 type writerForMarshals struct {
 	name          string
 	fileExtension string
-	outDir        string
+	out           Output
 	doc           Documentation
 	marshaller    MarshalFn
 	logger        Logger
@@ -178,13 +307,8 @@ func (w *writerForMarshals) write() error {
 	}
 
 	cleanName := internal.CleanPath(w.doc.RootCommand.Name)
-	docRoot := filepath.Join(w.outDir, cleanName)
-	if err := os.MkdirAll(docRoot, 0700); err != nil {
-		return err
-	}
-
-	docJson := filepath.Join(w.outDir, cleanName, fmt.Sprintf("%s.%s", cleanName, w.fileExtension))
-	err = os.WriteFile(docJson, data, 0700)
+	docJson := path.Join(cleanName, fmt.Sprintf("%s.%s", cleanName, w.fileExtension))
+	err = w.out.WriteFile(docJson, data)
 	if err == nil {
 		w.logger.Printf("[%s] Wrote file %s", w.name, docJson)
 	}
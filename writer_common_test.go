@@ -0,0 +1,164 @@
+package venom
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// assertXmlStructure checks that data is a well-formed XML document, that each element is in one of namespaces, and
+// that the children of each element match its content model. namespaces map each namespace to the prefix used to name
+// its elements in models, where children are matched as a sequence of prefixed names, each followed by a space.
+func assertXmlStructure(t *testing.T, name string, data []byte, namespaces map[string]string, models map[string]*regexp.Regexp) {
+	t.Helper()
+
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	children := [][]string{{}}
+	names := make([]string, 0)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Errorf("%s is not well-formed: %v", name, err)
+			}
+			return
+		}
+		switch el := token.(type) {
+		case xml.StartElement:
+			prefix, ok := namespaces[el.Name.Space]
+			if !ok {
+				t.Errorf("%s: element %s is in unknown namespace %q", name, el.Name.Local, el.Name.Space)
+			}
+			element := prefix + el.Name.Local
+			children[len(children)-1] = append(children[len(children)-1], element)
+			children = append(children, []string{})
+			names = append(names, element)
+		case xml.EndElement:
+			element := names[len(names)-1]
+			sequence := ""
+			for _, child := range children[len(children)-1] {
+				sequence += child + " "
+			}
+			if model, ok := models[element]; ok && !model.MatchString(sequence) {
+				t.Errorf("%s: %s has invalid children %q", name, element, sequence)
+			}
+			children = children[:len(children)-1]
+			names = names[:len(names)-1]
+		}
+	}
+}
+
+// requireToolsVariable is the environment variable which fails, rather than skips, tests whose external tools are missing
+const requireToolsVariable = "VENOM_REQUIRE_TOOLS"
+
+// requireTool provides the path of the named executable. The test is skipped when the tool isn't installed, unless the
+// tools are required by requireToolsVariable, as in CI.
+func requireTool(t *testing.T, name string) string {
+	t.Helper()
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		if os.Getenv(requireToolsVariable) != "" {
+			t.Fatalf("%s is required by %s: %v", name, requireToolsVariable, err)
+		}
+		t.Skipf("%s is not installed: %v", name, err)
+	}
+	return path
+}
+
+// validateXml validates documents, written to a temporary directory along with the other files, with xmllint against
+// schema. Options end with the flag which takes the schema, e.g. --relaxng or --schema. The test is skipped when the
+// schema isn't vendored, and requires xmllint as requireTool.
+func validateXml(t *testing.T, files map[string][]byte, documents []string, schema string, options ...string) {
+	t.Helper()
+
+	if _, err := os.Stat(schema); err != nil {
+		t.Skipf("schema %s is not vendored: %v", schema, err)
+	}
+	xmllint := requireTool(t, "xmllint")
+	schema, err := filepath.Abs(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, b := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), DefaultDirectoryMode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, b, DefaultFileMode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, document := range documents {
+		args := append([]string{"--noout"}, options...)
+		args = append(args, schema, filepath.Join(dir, filepath.FromSlash(document)))
+		if output, err := exec.Command(xmllint, args...).CombinedOutput(); err != nil {
+			t.Errorf("%s is invalid against %s: %v\n%s", document, filepath.Base(schema), err, output)
+		}
+	}
+}
+
+// newSimpleTestDocumentation provides the documentation of a "simple" command, as used by tests of the completion and
+// spec formats. It has local, persistent, hidden, deprecated, and required flags, a subcommand with valid args, a hidden
+// and deprecated subcommand with args, and a subcommand with a single arg. Each call provides a new value, so tests may
+// adjust it as needed.
+func newSimpleTestDocumentation(format string) Documentation {
+	return Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc " + format,
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Short:    "s <s>",
+			LocalFlags: []Flag{
+				{Name: "config", Shorthand: "c", Usage: "the `file` to read", Type: "string", Local: true},
+				{Name: "verbose", Shorthand: "v", Usage: "verbosity", Type: "count", DefValue: "0", Local: true},
+				{Name: "secret", Usage: "a hidden flag", Type: "bool", DefValue: "false", Hidden: true, Local: true},
+			},
+			PersistentFlags: []Flag{
+				{Name: "config", Shorthand: "c", Usage: "the `file` to read", Type: "string", Persistent: true},
+			},
+			Subcommands: []Command{
+				{
+					Name:      "command",
+					FullPath:  "simple command",
+					Short:     "c",
+					Aliases:   []string{"cmd"},
+					ValidArgs: []string{"x", "y"},
+					LocalFlags: []Flag{
+						{Name: "output", Shorthand: "o", ShorthandDeprecated: "use --output", Usage: "output format", Type: "string", DefValue: "json", NoOptDefVal: "yaml", Required: true, Local: true},
+						{Name: "tags", Usage: "tags", Type: "stringSlice", DefValue: "[]", Deprecated: "use --labels", Hidden: true, Local: true},
+					},
+					InheritedFlags: []Flag{
+						{Name: "config", Shorthand: "c", Usage: "the `file` to read", Type: "string", Inherited: true},
+					},
+				},
+				{
+					Name:       "hidden",
+					FullPath:   "simple hidden",
+					Short:      "h",
+					Hidden:     true,
+					Deprecated: "gone",
+					Args: []Arg{
+						{Name: "first", Description: "the first", Required: true},
+						{Name: "rest", Variadic: true, Values: []string{"a", "b"}},
+					},
+				},
+				{
+					Name:     "single",
+					FullPath: "simple single",
+					Args:     []Arg{{Name: "only", Required: true}},
+				},
+			},
+		},
+	}
+}
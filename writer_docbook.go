@@ -0,0 +1,189 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// docBookFormat is the name of the format which writes DocBook 5 refentries and a reference including them
+const docBookFormat = "DocBook"
+
+var docbookEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// docbookParagraphs separates paragraphs of help text, which are blank lines
+var docbookParagraphs = regexp.MustCompile(`\n[ \t]*\n`)
+
+type functionsDocBook struct {
+	// section is the manual volume of each refentry
+	section string
+}
+
+func (f functionsDocBook) FormatHeader(input string) string {
+	return docbookEscaper.Replace(input)
+}
+
+func (f functionsDocBook) FormatText(input string) string {
+	return docbookEscaper.Replace(stripansi.String(input))
+}
+
+func (f functionsDocBook) FormatOptions(input string) string {
+	return fmt.Sprintf("<screen>%s</screen>", f.FormatText(trimIndent(input, 2)))
+}
+
+// FormatFlag returns a varlistentry for the flag, with its option names and value as the term
+func (f functionsDocBook) FormatFlag(input Flag) string {
+	if input.Hidden {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString("<varlistentry>\n<term>")
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString(fmt.Sprintf("<option>-%s</option>, ", f.FormatText(input.Shorthand)))
+	}
+	buf.WriteString(fmt.Sprintf("<option>--%s</option>", f.FormatText(input.Name)))
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(fmt.Sprintf(" <replaceable>%s</replaceable>", f.FormatText(input.Type)))
+	}
+	buf.WriteString("</term>\n<listitem><para>")
+	buf.WriteString(f.FormatText(input.Usage))
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			defaultValue = fmt.Sprintf("%q", defaultValue)
+		}
+		buf.WriteString(fmt.Sprintf(" (default <literal>%s</literal>)", f.FormatText(defaultValue)))
+	}
+	if input.Required {
+		buf.WriteString(" (required)")
+	}
+	if input.Deprecated != "" {
+		buf.WriteString(fmt.Sprintf(" (DEPRECATED: %s)", f.FormatText(input.Deprecated)))
+	}
+	buf.WriteString("</para></listitem>\n</varlistentry>")
+	return buf.String()
+}
+
+// SeeAlsoPath returns a man page style reference to the refentry of the command at input
+func (f functionsDocBook) SeeAlsoPath(input string) string {
+	return fmt.Sprintf("<citerefentry><refentrytitle>%s</refentrytitle><manvolnum>%s</manvolnum></citerefentry>",
+		f.refEntryTitle(input), f.FormatText(f.section))
+}
+
+func (f functionsDocBook) FormatExample(input string) string {
+	// markdown code fences are replaced by a programlisting
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return fmt.Sprintf(`<programlisting language="shell">%s</programlisting>`, f.FormatText(trimIndent(replaced, -1)))
+}
+
+// FormatAutoGenTag returns input for use within an XML comment, which may not contain a double hyphen
+func (f functionsDocBook) FormatAutoGenTag(input string) string {
+	for strings.Contains(input, "--") {
+		input = strings.ReplaceAll(input, "--", "- -")
+	}
+	return input
+}
+
+func (f functionsDocBook) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides docbook-specific template functions
+func (f functionsDocBook) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"commands":      f.commands,
+		"id":            f.id,
+		"page":          f.page,
+		"paras":         f.paras,
+		"refentrytitle": f.refEntryTitle,
+		"section":       func() string { return f.FormatText(f.section) },
+	}
+}
+
+// commands flattens the tree of visible commands under c, in depth-first order
+func (f functionsDocBook) commands(c Command) []Command {
+	result := make([]Command, 0)
+	for _, section := range sectionCommands(c, []string{"refentry"}) {
+		result = append(result, section.Command)
+	}
+	return result
+}
+
+// id is the xml:id of the refentry documenting the command at input, which must be a valid NCName
+func (f functionsDocBook) id(input string) string {
+	id := internal.CleanPath(input)
+	id = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, id)
+	if first := []rune(id); len(first) == 0 || !(unicode.IsLetter(first[0]) || first[0] == '_') {
+		id = "_" + id
+	}
+	return id
+}
+
+// page is the file name of the refentry documenting the command at input, relative to the reference
+func (f functionsDocBook) page(input string) string {
+	return f.FormatText(internal.CleanPath(input) + ".xml")
+}
+
+// paras splits help text into a para element per paragraph
+func (f functionsDocBook) paras(input string) string {
+	paragraphs := make([]string, 0)
+	for _, paragraph := range docbookParagraphs.Split(strings.TrimSpace(input), -1) {
+		if paragraph != "" {
+			paragraphs = append(paragraphs, fmt.Sprintf("<para>%s</para>", f.FormatText(paragraph)))
+		}
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// refEntryTitle is the title of the command at input as a man page, e.g. app-alpha
+func (f functionsDocBook) refEntryTitle(input string) string {
+	return f.FormatText(internal.CleanPath(input, "-"))
+}
+
+type writerDocBook struct {
+	options TemplateOptions
+}
+
+func (w *writerDocBook) Write(out Output, doc Documentation) error {
+	section := w.options.ManSection
+	if section == "" {
+		section = "1"
+	}
+
+	helper := writerForTemplates{
+		name:          docBookFormat,
+		fileExtension: "xml",
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         functionsDocBook{section: section},
+		includeIndex:  true,
+	}
+
+	return helper.write()
+}
+
+func (w *writerDocBook) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(docBookFormat, []string{"docbook5"}, "xml", func() Writer {
+		return &writerDocBook{}
+	})
+}
+
+var (
+	_ functions         = (*functionsDocBook)(nil)
+	_ extendedFunctions = (*functionsDocBook)(nil)
+)
@@ -0,0 +1,240 @@
+package venom
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDocBookWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc docbook",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags] <name>",
+			Short:    "s & <s>",
+			Long:     "simple doc docbook.\n\nA second \"paragraph\".",
+			Version:  "1.0.0",
+			Args: []Arg{
+				{Name: "NAME", Description: "the name", Required: true, Variadic: true, Values: []string{"a", "b"}},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an <output> flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+				{
+					Name:   "secret",
+					Usage:  "a hidden flag",
+					Type:   "string",
+					Hidden: true,
+				},
+			},
+			FlagGroups: []FlagGroup{
+				{Kind: FlagGroupMutuallyExclusive, Flags: []string{"testing", "output"}},
+			},
+			Runnable: true,
+			Groups:   []CommandGroup{{ID: "main", Title: "Main Commands:"}},
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Aliases:  []string{"cmd"},
+					GroupID:  "main",
+					Runnable: true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s & <s>", FullPath: "simple"},
+					Examples: []string{"simple command --testing '<x>'"},
+					InheritedFlags: []Flag{
+						{Name: "verbose", Usage: "be verbose", Type: "bool", Inherited: true},
+					},
+					Deprecated: "use other",
+				},
+				{
+					Name:      "other",
+					FullPath:  "simple other",
+					Short:     "o",
+					ValidArgs: []string{"x", "y"},
+					Parent:    &ParentCommand{Name: "simple", Short: "s & <s>", FullPath: "simple"},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s & <s>", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		files    map[string][]string
+		excludes map[string][]string
+		missing  []string
+		// validate the files against DocBook 5, which custom templates needn't follow
+		validate bool
+	}{
+		{
+			name: "writes docbook refentries and reference",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.xml": {
+					"<!-- generated by: Simple doc docbook 1-Jan-2023 -->",
+					`<refentry xmlns="http://docbook.org/ns/docbook" version="5.0" xml:id="simple">`,
+					"<refentrytitle>simple</refentrytitle>\n    <manvolnum>1</manvolnum>",
+					`<refmiscinfo class="version">1.0.0</refmiscinfo>`,
+					"<refname>simple</refname>\n    <refpurpose>s &amp; &lt;s&gt;</refpurpose>",
+					"<refsynopsisdiv>\n    <synopsis>simple [flags] &lt;name&gt;</synopsis>\n  </refsynopsisdiv>",
+					"<para>simple doc docbook.</para>\n<para>A second &quot;paragraph&quot;.</para>",
+					`<refmiscinfo class="other" otherclass="date">1-Jan-2023</refmiscinfo>`,
+					"<term><replaceable>NAME</replaceable>...</term>\n        <listitem><para>the name (required)</para><para>Allowed values: <literal>a</literal>, <literal>b</literal></para></listitem>",
+					"<term><option>-t</option>, <option>--testing</option></term>\n<listitem><para>a test flag</para></listitem>",
+					"<term><option>--output</option> <replaceable>string</replaceable></term>\n<listitem><para>an &lt;output&gt; flag (default <literal>&quot;json&quot;</literal>) (required)</para></listitem>",
+					"Mutually exclusive: <option>--testing</option>, <option>--output</option>",
+					"<title>Main Commands</title>\n      <listitem><para><citerefentry><refentrytitle>simple-command</refentrytitle><manvolnum>1</manvolnum></citerefentry> - c</para></listitem>",
+				},
+				"simple_command.xml": {
+					`xml:id="simple_command"`,
+					"<refname>simple command</refname>\n    <refname>simple cmd</refname>",
+					"<warning><para>Deprecated: use other</para></warning>",
+					`<programlisting language="shell">simple command --testing '&lt;x&gt;'</programlisting>`,
+					"<title>Options inherited from parent commands</title>",
+					"<citerefentry><refentrytitle>simple</refentrytitle><manvolnum>1</manvolnum></citerefentry> - s &amp; &lt;s&gt;",
+				},
+				"simple_other.xml": {
+					"<title>Description</title>\n    <para>o</para>",
+					"<para>Valid arguments: <literal>x</literal>, <literal>y</literal></para>",
+				},
+				"index.xml": {
+					`<reference xmlns="http://docbook.org/ns/docbook" xmlns:xi="http://www.w3.org/2001/XInclude" version="5.0" xml:id="simple.reference">`,
+					"<title>simple</title>",
+					"<partintro>\n    <para>s &amp; &lt;s&gt;</para>\n  </partintro>",
+					"<xi:include href=\"simple.xml\"/>\n  <xi:include href=\"simple_command.xml\"/>\n  <xi:include href=\"simple_other.xml\"/>\n</reference>",
+				},
+			},
+			excludes: map[string][]string{
+				"simple.xml":       {"--secret", "simple-hidden", "<refsection>\n    <title>Examples"},
+				"simple_other.xml": {"<refsynopsisdiv>"},
+				"index.xml":        {"simple_hidden.xml"},
+			},
+			validate: true,
+		},
+		{
+			name: "uses the configured manual section",
+			args: args{options: NewOptions().WithManSection("8").TemplateOptions()},
+			files: map[string][]string{
+				"simple_command.xml": {
+					"<manvolnum>8</manvolnum>",
+					"<refentrytitle>simple</refentrytitle><manvolnum>8</manvolnum>",
+				},
+			},
+			validate: true,
+		},
+		{
+			name: "skips reference not provided by custom templates",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/docbook_command.tmpl": &fstest.MapFile{Data: []byte(`<refentry xmlns="http://docbook.org/ns/docbook" version="5.0"><refnamediv><refname>{{ .FullPath }}</refname><refpurpose/></refnamediv></refentry>`)},
+			}).TemplateOptions()},
+			files: map[string][]string{
+				"simple.xml": {"<refname>simple</refname>"},
+			},
+			missing: []string{"index.xml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerDocBook{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerDocBook() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerDocBook() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerDocBook() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+				for _, exclude := range tt.excludes[name] {
+					if strings.Contains(string(b), exclude) {
+						t.Errorf("writerDocBook() %s unexpectedly contains %q in:\n%s", name, exclude, string(b))
+					}
+				}
+			}
+
+			for name, b := range files {
+				models := docbookContentModels
+				if !tt.validate {
+					models = nil
+				}
+				assertXmlStructure(t, name, b, docbookNamespaces, models)
+			}
+			if tt.validate {
+				// the reference is validated with its refentries included, as is each refentry on its own
+				t.Run("schema", func(t *testing.T) {
+					validateXml(t, files, out.Names(), "testdata/docbook/docbook.rng", "--xinclude", "--relaxng")
+				})
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerDocBook() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
+
+// docbookNamespaces are the namespaces of the elements venom emits, with the prefixes used in docbookContentModels
+var docbookNamespaces = map[string]string{
+	"http://docbook.org/ns/docbook":   "",
+	"http://www.w3.org/2001/XInclude": "xi:",
+}
+
+// docbookContentModels are the DocBook 5 content models of the elements venom emits, restricted to the children venom
+// may write. These cover the subset of the schema relevant to venom's templates, and are checked whether or not the
+// DocBook 5 RELAX NG schema is available for validation.
+var docbookContentModels = map[string]*regexp.Regexp{
+	"reference":      regexp.MustCompile(`^title (partintro )?(xi:include )+$`),
+	"partintro":      regexp.MustCompile(`^(para )+$`),
+	"refentry":       regexp.MustCompile(`^refmeta refnamediv (refsynopsisdiv )?(refsection )+$`),
+	"refmeta":        regexp.MustCompile(`^refentrytitle (manvolnum )?(refmiscinfo )*$`),
+	"refnamediv":     regexp.MustCompile(`^(refname )+refpurpose $`),
+	"refsynopsisdiv": regexp.MustCompile(`^(synopsis )+$`),
+	"refsection":     regexp.MustCompile(`^title ((para|variablelist|itemizedlist|programlisting|warning) )+$`),
+	"variablelist":   regexp.MustCompile(`^(varlistentry )+$`),
+	"varlistentry":   regexp.MustCompile(`^(term )+listitem $`),
+	"itemizedlist":   regexp.MustCompile(`^(title )?(listitem )+$`),
+	"listitem":       regexp.MustCompile(`^(para )+$`),
+	"warning":        regexp.MustCompile(`^(para )+$`),
+	"citerefentry":   regexp.MustCompile(`^refentrytitle (manvolnum )?$`),
+	"para":           regexp.MustCompile(`^((literal|option|replaceable|citerefentry) )*$`),
+	"term":           regexp.MustCompile(`^((literal|option|replaceable) )*$`),
+	"xi:include":     regexp.MustCompile(`^$`),
+}
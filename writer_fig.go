@@ -0,0 +1,188 @@
+package venom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/jimschubert/venom/internal"
+	"regexp"
+	"strings"
+)
+
+// figFormat is the name of the format which writes a Fig completion spec
+const figFormat = "Fig"
+
+// figSpecKey matches the quoted keys of indented JSON objects, which are written unquoted as is conventional in specs
+var figSpecKey = regexp.MustCompile(`(?m)^(\s*)"([A-Za-z]+)":`)
+
+// figSubcommand is a Fig.Subcommand, see https://fig.io/docs/reference/subcommand
+type figSubcommand struct {
+	Name        interface{}     `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Hidden      bool            `json:"hidden,omitempty"`
+	Deprecated  *figDeprecation `json:"deprecated,omitempty"`
+	Subcommands []figSubcommand `json:"subcommands,omitempty"`
+	Options     []figOption     `json:"options,omitempty"`
+	Args        interface{}     `json:"args,omitempty"`
+}
+
+// figOption is a Fig.Option, see https://fig.io/docs/reference/option
+type figOption struct {
+	Name         interface{}     `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	IsPersistent bool            `json:"isPersistent,omitempty"`
+	IsRequired   bool            `json:"isRequired,omitempty"`
+	IsRepeatable bool            `json:"isRepeatable,omitempty"`
+	Hidden       bool            `json:"hidden,omitempty"`
+	Deprecated   *figDeprecation `json:"deprecated,omitempty"`
+	Args         *figArg         `json:"args,omitempty"`
+}
+
+// figArg is a Fig.Arg, see https://fig.io/docs/reference/arg
+type figArg struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	IsOptional  bool     `json:"isOptional,omitempty"`
+	IsVariadic  bool     `json:"isVariadic,omitempty"`
+}
+
+type figDeprecation struct {
+	Description string `json:"description,omitempty"`
+}
+
+// figName is a single name, or all names of a suggestion when it has more than one
+func figName(names ...string) interface{} {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return names
+}
+
+func newFigSubcommand(c Command) figSubcommand {
+	result := figSubcommand{
+		Name:        figName(append([]string{c.Name}, c.Aliases...)...),
+		Description: c.Short,
+		Hidden:      c.Hidden,
+	}
+	if c.Deprecated != "" {
+		result.Deprecated = &figDeprecation{Description: c.Deprecated}
+	}
+
+	for _, sub := range c.Subcommands {
+		result.Subcommands = append(result.Subcommands, newFigSubcommand(sub))
+	}
+
+	// inherited flags are omitted, as they're persistent options of an ancestor which Fig already applies
+	persistent := make(map[string]bool)
+	for _, flag := range c.PersistentFlags {
+		persistent[flag.Name] = true
+	}
+	for _, flag := range c.LocalFlags {
+		result.Options = append(result.Options, newFigOption(flag, persistent[flag.Name]))
+	}
+
+	switch {
+	case len(c.Args) == 1:
+		result.Args = newFigArg(c.Args[0])
+	case len(c.Args) > 1:
+		args := make([]figArg, 0, len(c.Args))
+		for _, arg := range c.Args {
+			args = append(args, newFigArg(arg))
+		}
+		result.Args = args
+	case len(c.ValidArgs) > 0:
+		// cobra doesn't require valid args, unless validated by the command's Args
+		result.Args = figArg{Name: "arg", Suggestions: c.ValidArgs, IsOptional: true}
+	}
+	return result
+}
+
+func newFigArg(arg Arg) figArg {
+	return figArg{
+		Name:        arg.Name,
+		Description: arg.Description,
+		Suggestions: arg.Values,
+		IsOptional:  !arg.Required,
+		IsVariadic:  arg.Variadic,
+	}
+}
+
+func newFigOption(flag Flag, persistent bool) figOption {
+	names := make([]string, 0, 2)
+	if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+		names = append(names, "-"+flag.Shorthand)
+	}
+	names = append(names, "--"+flag.Name)
+
+	name, usage := unquoteUsage(flag)
+	result := figOption{
+		Name:         figName(names...),
+		Description:  usage,
+		IsPersistent: persistent,
+		IsRequired:   flag.Required,
+		// slices accumulate values, and counts are incremented on each occurrence
+		IsRepeatable: flag.Type == "count" || strings.HasSuffix(flag.Type, "Slice") || strings.HasSuffix(flag.Type, "Array"),
+		Hidden:       flag.Hidden,
+	}
+	if flag.Deprecated != "" {
+		result.Deprecated = &figDeprecation{Description: flag.Deprecated}
+	}
+
+	if flag.Type != "bool" && flag.Type != "count" {
+		result.Args = &figArg{
+			Name:       name,
+			Default:    internal.DefaultValue(flag.Type, flag.DefValue, flag.DefValue),
+			IsOptional: flag.NoOptDefVal != "",
+		}
+	}
+	return result
+}
+
+type writerFig struct {
+	options TemplateOptions
+}
+
+func (w *writerFig) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func (w *writerFig) Write(out Output, doc Documentation) error {
+	helper := writerForMarshals{
+		name:          figFormat,
+		fileExtension: "ts",
+		out:           out,
+		doc:           doc,
+		marshaller:    w.marshal,
+		logger:        w.options.Logger,
+	}
+	return helper.write()
+}
+
+// marshal encodes the documentation as a TypeScript module exporting a Fig.Spec
+func (w *writerFig) marshal(v interface{}) ([]byte, error) {
+	doc := v.(*Documentation)
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(newFigSubcommand(doc.RootCommand)); err != nil {
+		return nil, err
+	}
+	spec := figSpecKey.ReplaceAllString(strings.TrimSuffix(buf.String(), "\n"), "$1$2:")
+
+	result := strings.Builder{}
+	if doc.AutoGenerationTag != "" {
+		result.WriteString(fmt.Sprintf("// %s %s\n", doc.AutoGenerationTag, doc.GenerationDate))
+	}
+	result.WriteString(fmt.Sprintf("const completionSpec: Fig.Spec = %s;\n\nexport default completionSpec;\n", spec))
+	return []byte(result.String()), nil
+}
+
+func init() {
+	mustRegisterFormat(figFormat, []string{"amazonq"}, "ts", func() Writer {
+		return &writerFig{}
+	})
+}
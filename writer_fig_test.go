@@ -0,0 +1,128 @@
+package venom
+
+import (
+	"testing"
+)
+
+func TestFigWrite(t *testing.T) {
+	doc := newSimpleTestDocumentation("fig")
+
+	want := `// generated by: Simple doc fig 1-Jan-2023
+const completionSpec: Fig.Spec = {
+  name: "simple",
+  description: "s <s>",
+  subcommands: [
+    {
+      name: [
+        "command",
+        "cmd"
+      ],
+      description: "c",
+      options: [
+        {
+          name: "--output",
+          description: "output format",
+          isRequired: true,
+          args: {
+            name: "string",
+            default: "json",
+            isOptional: true
+          }
+        },
+        {
+          name: "--tags",
+          description: "tags",
+          isRepeatable: true,
+          hidden: true,
+          deprecated: {
+            description: "use --labels"
+          },
+          args: {
+            name: "strings"
+          }
+        }
+      ],
+      args: {
+        name: "arg",
+        suggestions: [
+          "x",
+          "y"
+        ],
+        isOptional: true
+      }
+    },
+    {
+      name: "hidden",
+      description: "h",
+      hidden: true,
+      deprecated: {
+        description: "gone"
+      },
+      args: [
+        {
+          name: "first",
+          description: "the first"
+        },
+        {
+          name: "rest",
+          suggestions: [
+            "a",
+            "b"
+          ],
+          isOptional: true,
+          isVariadic: true
+        }
+      ]
+    },
+    {
+      name: "single",
+      args: {
+        name: "only"
+      }
+    }
+  ],
+  options: [
+    {
+      name: [
+        "-c",
+        "--config"
+      ],
+      description: "the file to read",
+      isPersistent: true,
+      args: {
+        name: "file"
+      }
+    },
+    {
+      name: [
+        "-v",
+        "--verbose"
+      ],
+      description: "verbosity",
+      isRepeatable: true
+    },
+    {
+      name: "--secret",
+      description: "a hidden flag",
+      hidden: true
+    }
+  ]
+};
+
+export default completionSpec;
+`
+
+	out := NewMemoryOutput()
+	w := writerFig{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerFig() error = %v", err)
+	}
+
+	got, ok := out.Files()["simple/simple.ts"]
+	if !ok {
+		t.Fatalf("writerFig() missing file simple.ts in %v", out.Names())
+	}
+	if string(got) != want {
+		t.Errorf("writerFig() got\n%s\nwant\n%s", got, want)
+	}
+}
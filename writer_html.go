@@ -0,0 +1,152 @@
+package venom
+
+import (
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+)
+
+// htmlFormat is the name of the format which writes a static HTML site
+const htmlFormat = "Html"
+
+// htmlBreadcrumb is a single entry in the breadcrumb trail of a command page
+type htmlBreadcrumb struct {
+	Name string
+	Path string
+}
+
+// htmlNavEntry is a node of the sidebar tree, marking the command currently being viewed
+type htmlNavEntry struct {
+	Name     string
+	FullPath string
+	Short    string
+	Current  bool
+	Children []htmlNavEntry
+}
+
+type functionsHtml struct {
+}
+
+func (f functionsHtml) FormatHeader(input string) string {
+	return input
+}
+
+// FormatText strips ANSI sequences only; html/template handles escaping in the context of the output
+func (f functionsHtml) FormatText(input string) string {
+	return stripansi.String(input)
+}
+
+func (f functionsHtml) FormatOptions(input string) string {
+	return trimIndent(stripansi.String(input), 2)
+}
+
+// FormatFlag returns the flag's signature, e.g. "-o, --output string"
+func (f functionsHtml) FormatFlag(input Flag) string {
+	buf := strings.Builder{}
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString("-" + input.Shorthand + ", ")
+	}
+	buf.WriteString("--" + input.Name)
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(" " + input.Type)
+	}
+	return buf.String()
+}
+
+func (f functionsHtml) SeeAlsoPath(input string) string {
+	return internal.CleanPath(input)
+}
+
+func (f functionsHtml) FormatExample(input string) string {
+	// code fences used for markdown are meaningless in a <pre> block
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return trimIndent(stripansi.String(replaced), -1)
+}
+
+func (f functionsHtml) FormatAutoGenTag(input string) string {
+	return input
+}
+
+func (f functionsHtml) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides html-specific template functions
+func (f functionsHtml) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"breadcrumbs": f.breadcrumbs,
+		"nav":         f.nav,
+		"default_value": func(input Flag) string {
+			return internal.DefaultValue(input.Type, input.DefValue, input.DefValue)
+		},
+	}
+}
+
+// breadcrumbs splits a command's full path into links to each ancestor, ending with the command itself
+func (f functionsHtml) breadcrumbs(fullPath string) []htmlBreadcrumb {
+	names := strings.Fields(fullPath)
+	crumbs := make([]htmlBreadcrumb, 0, len(names))
+	for i, name := range names {
+		crumbs = append(crumbs, htmlBreadcrumb{
+			Name: name,
+			Path: f.SeeAlsoPath(strings.Join(names[:i+1], " ")),
+		})
+	}
+	return crumbs
+}
+
+// nav builds the sidebar tree of visible commands under c, marking the command at current
+func (f functionsHtml) nav(c Command, current string) htmlNavEntry {
+	entry := htmlNavEntry{
+		Name:     c.Name,
+		FullPath: c.FullPath,
+		Short:    c.Short,
+		Current:  c.FullPath == current,
+		Children: make([]htmlNavEntry, 0),
+	}
+	for _, sub := range c.Subcommands {
+		if !sub.Hidden {
+			entry.Children = append(entry.Children, f.nav(sub, current))
+		}
+	}
+	return entry
+}
+
+type writerHtml struct {
+	options TemplateOptions
+}
+
+func (w *writerHtml) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:          htmlFormat,
+		fileExtension: "html",
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         functionsHtml{},
+		includeIndex:  true,
+		escapeHTML:    true,
+		assets: []templateAsset{
+			{target: "style", name: "style.css"},
+		},
+	}
+
+	return helper.write()
+}
+
+func (w *writerHtml) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(htmlFormat, []string{"htm"}, "html", func() Writer {
+		return &writerHtml{}
+	})
+}
+
+var (
+	_ functions         = (*functionsHtml)(nil)
+	_ extendedFunctions = (*functionsHtml)(nil)
+)
@@ -0,0 +1,159 @@
+package venom
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHtmlWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc html",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s <script>",
+			Long:     "simple doc html & more",
+			Args: []Arg{
+				{Name: "NAME", Description: "the name", Required: true},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an <output> flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+				{
+					Name:   "secret",
+					Usage:  "a hidden flag",
+					Type:   "string",
+					Hidden: true,
+				},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Parent:   &ParentCommand{Name: "simple", Short: "s <script>", FullPath: "simple"},
+					Examples: []string{"simple command --testing '<x>'"},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s <script>", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		files    map[string][]string
+		excludes map[string][]string
+		missing  []string
+	}{
+		{
+			name: "writes html site",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.html": {
+					"<title>simple</title>",
+					`<link rel="stylesheet" href="style.css">`,
+					`<li><a href="simple.html" class="current" aria-current="page" title="s &lt;script&gt;">simple</a>`,
+					`<li><a href="simple_command.html" title="c">command</a></li>`,
+					`<p class="short">s &lt;script&gt;</p>`,
+					`<div class="long">simple doc html &amp; more</div>`,
+					`<pre class="usage"><code>simple [flags]</code></pre>`,
+					`<dt><code>NAME</code> <span class="badge">required</span></dt>`,
+					`<tr><td><code>-t, --testing</code></td><td></td><td>a test flag</td></tr>`,
+					`<tr><td><code>--output string</code></td><td><code>json</code></td><td>an &lt;output&gt; flag <span class="badge">required</span></td></tr>`,
+					`<li><a href="simple_command.html">simple command</a> - c</li>`,
+					"<footer>generated by: Simple doc html 1-Jan-2023</footer>",
+				},
+				"simple_command.html": {
+					"<title>simple command</title>",
+					"<li><a href=\"simple.html\">simple</a></li>\n<li><a href=\"simple_command.html\">command</a></li>",
+					`<li><a href="simple_command.html" class="current" aria-current="page" title="c">command</a></li>`,
+					`<pre class="example"><code>simple command --testing &#39;&lt;x&gt;&#39;</code></pre>`,
+					`<li><a href="simple.html">simple</a> - s &lt;script&gt;</li>`,
+				},
+				"index.html": {
+					"<h1>simple</h1>",
+					`<li><a href="simple.html">simple</a> - s &lt;script&gt;</li>`,
+					`<li><a href="simple_command.html">simple command</a> - c</li>`,
+				},
+				"style.css": {
+					".sidebar {",
+				},
+			},
+			excludes: map[string][]string{
+				"simple.html": {"<script>", "--secret", "simple_hidden.html"},
+			},
+		},
+		{
+			name: "skips assets not provided by custom templates",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/html_command.tmpl": &fstest.MapFile{Data: []byte("<h1>{{ .FullPath }}</h1>")},
+			}).TemplateOptions()},
+			files: map[string][]string{
+				"simple.html":         {"<h1>simple</h1>"},
+				"simple_command.html": {"<h1>simple command</h1>"},
+			},
+			missing: []string{"index.html", "style.css"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerHtml{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerHtml() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerHtml() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerHtml() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+				for _, exclude := range tt.excludes[name] {
+					if strings.Contains(string(b), exclude) {
+						t.Errorf("writerHtml() %s unexpectedly contains %q in:\n%s", name, exclude, string(b))
+					}
+				}
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerHtml() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
@@ -8,11 +8,11 @@ func (w *writerJson) SetTemplateOptions(options TemplateOptions) {
 	w.options = options
 }
 
-func (w *writerJson) Write(outDir string, doc Documentation) error {
+func (w *writerJson) Write(out Output, doc Documentation) error {
 	helper := writerForMarshals{
 		name:          Json.String(),
 		fileExtension: "json",
-		outDir:        outDir,
+		out:           out,
 		doc:           doc,
 		marshaller:    w.options.JsonMarshaler,
 		logger:        w.options.Logger,
@@ -21,7 +21,7 @@ func (w *writerJson) Write(outDir string, doc Documentation) error {
 }
 
 func init() {
-	registerWriter(Json, func() writer {
+	mustRegisterFormat(Json.String(), nil, "json", func() Writer {
 		return &writerJson{}
 	})
 }
@@ -64,7 +64,7 @@ func TestJsonWrite(t *testing.T) {
 				options: tt.args.options,
 			}
 
-			if err := w.Write(outDir, tt.args.doc); (err != nil) != tt.wantErr {
+			if err := w.Write(NewDirectoryOutput(outDir), tt.args.doc); (err != nil) != tt.wantErr {
 				t.Fatalf("writerJson() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -0,0 +1,186 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+)
+
+// latexFormat is the name of the format which writes a LaTeX manual
+const latexFormat = "Latex"
+
+// latexSectioning are the sectioning commands by depth in the command tree; deeper commands reuse the last
+var latexSectioning = []string{"chapter", "section", "subsection", "subsubsection", "paragraph"}
+
+// latexIndexEscaper quotes characters which makeindex interprets in index entries
+var latexIndexEscaper = strings.NewReplacer(`"`, `""`, "!", `"!`, "@", `"@`, "|", `"|`)
+
+type functionsLatex struct {
+}
+
+func (f functionsLatex) FormatHeader(input string) string {
+	return escapeLatex(input)
+}
+
+func (f functionsLatex) FormatText(input string) string {
+	return escapeLatex(stripansi.String(input))
+}
+
+func (f functionsLatex) FormatOptions(input string) string {
+	return f.verbatim(trimIndent(input, 2))
+}
+
+// FormatFlag returns a longtable row for the flag. Flags are added to the index only where they are declared, rather
+// than on every command inheriting them.
+func (f functionsLatex) FormatFlag(input Flag) string {
+	if input.Hidden {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString(`\texttt{`)
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString(f.FormatText("-"+input.Shorthand) + ", ")
+	}
+	buf.WriteString(f.FormatText("--" + input.Name))
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(fmt.Sprintf(` \textit{%s}`, f.FormatText(input.Type)))
+	}
+	buf.WriteString("}")
+	if !input.Inherited {
+		buf.WriteString(fmt.Sprintf(`\index{%s@\texttt{%s}}`, latexIndexEscaper.Replace(input.Name), latexIndexEscaper.Replace(f.FormatText("--"+input.Name))))
+	}
+
+	buf.WriteString(" & ")
+	buf.WriteString(f.FormatText(input.Usage))
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			defaultValue = fmt.Sprintf("%q", defaultValue)
+		}
+		buf.WriteString(fmt.Sprintf(` (default \texttt{%s})`, f.FormatText(defaultValue)))
+	}
+	if input.Required {
+		buf.WriteString(" (required)")
+	}
+	if input.Deprecated != "" {
+		buf.WriteString(fmt.Sprintf(" (DEPRECATED: %s)", f.FormatText(input.Deprecated)))
+	}
+	buf.WriteString(` \\`)
+	return buf.String()
+}
+
+// SeeAlsoPath returns a hyperlink to the section of the command at input, along with its page number for print
+func (f functionsLatex) SeeAlsoPath(input string) string {
+	return fmt.Sprintf(`\hyperref[%[1]s]{%[2]s} (p.~\pageref{%[1]s})`, f.label(input), f.FormatText(input))
+}
+
+func (f functionsLatex) FormatExample(input string) string {
+	// markdown code fences are replaced by a verbatim environment
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return f.verbatim(trimIndent(replaced, -1))
+}
+
+func (f functionsLatex) FormatAutoGenTag(input string) string {
+	return f.FormatText(input)
+}
+
+func (f functionsLatex) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides latex-specific template functions
+func (f functionsLatex) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"label":    f.label,
+		"sections": f.sections,
+		"verbatim": f.verbatim,
+	}
+}
+
+// label is the cross-reference label of the section documenting the command at input
+func (f functionsLatex) label(input string) string {
+	return "cmd:" + internal.CleanPath(input)
+}
+
+// sections flattens the tree of visible commands under c into chapters and sections, in depth-first order
+func (f functionsLatex) sections(c Command) []sectionedCommand {
+	return sectionCommands(c, latexSectioning)
+}
+
+// verbatim returns input in a verbatim environment, which requires no escaping other than of its own end
+func (f functionsLatex) verbatim(input string) string {
+	content := strings.ReplaceAll(stripansi.String(input), `\end{verbatim}`, `\end {verbatim}`)
+	return fmt.Sprintf("\\begin{verbatim}\n%s\n\\end{verbatim}", content)
+}
+
+// escapeLatex escapes LaTeX special characters in input, along with consecutive hyphens which LaTeX would otherwise
+// typeset as dashes (e.g. in --flag)
+func escapeLatex(input string) string {
+	buf := strings.Builder{}
+	for i, r := range input {
+		switch r {
+		case '\\':
+			buf.WriteString(`\textbackslash{}`)
+		case '{', '}', '$', '&', '#', '%', '_':
+			buf.WriteRune('\\')
+			buf.WriteRune(r)
+		case '^':
+			buf.WriteString(`\textasciicircum{}`)
+		case '~':
+			buf.WriteString(`\textasciitilde{}`)
+		case '<':
+			buf.WriteString(`\textless{}`)
+		case '>':
+			buf.WriteString(`\textgreater{}`)
+		case '|':
+			buf.WriteString(`\textbar{}`)
+		case '-':
+			buf.WriteRune(r)
+			if i+1 < len(input) && input[i+1] == '-' {
+				buf.WriteString("{}")
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+type writerLatex struct {
+	options TemplateOptions
+}
+
+func (w *writerLatex) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:           latexFormat,
+		fileExtension:  "tex",
+		out:            out,
+		doc:            doc,
+		options:        w.options,
+		funcs:          functionsLatex{},
+		singleDocument: true,
+		assets: []templateAsset{
+			{target: "manual", name: internal.CleanPath(doc.RootCommand.Name) + ".tex"},
+		},
+	}
+
+	return helper.write()
+}
+
+func (w *writerLatex) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(latexFormat, []string{"tex"}, "tex", func() Writer {
+		return &writerLatex{}
+	})
+}
+
+var (
+	_ functions         = (*functionsLatex)(nil)
+	_ extendedFunctions = (*functionsLatex)(nil)
+)
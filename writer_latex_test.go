@@ -0,0 +1,218 @@
+package venom
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLatexWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc latex",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags] {x}",
+			Short:    "s & {s}",
+			Long:     "simple doc latex, 100% $HOME_DIR ~/x #1 ^2 a\\b <in> | --flag",
+			Version:  "1.0.0",
+			Args: []Arg{
+				{Name: "NAME", Description: "the name", Required: true, Variadic: true},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an output_flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+				{
+					Name:   "secret",
+					Usage:  "a hidden flag",
+					Type:   "string",
+					Hidden: true,
+				},
+			},
+			FlagGroups: []FlagGroup{
+				{Kind: FlagGroupMutuallyExclusive, Flags: []string{"testing", "output"}},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Runnable: true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s & {s}", FullPath: "simple"},
+					Examples: []string{"simple command --testing '{x}' \\\n  --output=100%"},
+					InheritedFlags: []Flag{
+						{Name: "verbose", Usage: "be verbose", Type: "bool", Inherited: true},
+					},
+					Subcommands: []Command{
+						{
+							Name:     "nested",
+							FullPath: "simple command nested",
+							Short:    "n",
+							Parent:   &ParentCommand{Name: "command", Short: "c", FullPath: "simple command"},
+						},
+					},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s & {s}", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		files    map[string][]string
+		excludes map[string][]string
+		missing  []string
+	}{
+		{
+			name: "writes latex document",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.tex": {
+					"\\documentclass[11pt]{report}\n",
+					"\\usepackage{longtable}\n",
+					"\\makeindex\n",
+					"\\title{simple\\\\[1ex]\\large s \\& \\{s\\}\\\\[1ex]\\large Version 1.0.0}\n",
+					"\\date{generated by: Simple doc latex 1-Jan-2023}\n",
+					"\\maketitle\n\\tableofcontents\n",
+					"\\chapter{simple}\\label{cmd:simple}\n\ns \\& \\{s\\}\n",
+					"simple doc latex, 100\\% \\$HOME\\_DIR \\textasciitilde{}/x \\#1 \\textasciicircum{}2 a\\textbackslash{}b \\textless{}in\\textgreater{} \\textbar{} -{}-flag",
+					"\\venomheading{Usage}\n\\begin{verbatim}\nsimple [flags] {x}\n\\end{verbatim}",
+					"\\begin{description}\n\\item[\\texttt{NAME...}] the name (required)\n\\end{description}",
+					"\\begin{longtable}",
+					"\\texttt{-t, -{}-testing}\\index{testing@\\texttt{-{}-testing}} & a test flag \\\\\n",
+					"\\texttt{-{}-output \\textit{string}}\\index{output@\\texttt{-{}-output}} & an output\\_flag (default \\texttt{\"json\"}) (required) \\\\\n\\end{longtable}",
+					"\\item Mutually exclusive: \\texttt{-{}-testing}, \\texttt{-{}-output}",
+					"\\item \\hyperref[cmd:simple_command]{simple command} (p.~\\pageref{cmd:simple_command}) -- c",
+					"\\section{simple command}\\label{cmd:simple_command}",
+					"\\begin{verbatim}\nsimple command --testing '{x}' \\\n  --output=100%\n\\end{verbatim}",
+					"\\texttt{-{}-verbose} & be verbose \\\\\n",
+					"\\item \\hyperref[cmd:simple]{simple} (p.~\\pageref{cmd:simple}) -- s \\& \\{s\\}",
+					"\\subsection{simple command nested}\\label{cmd:simple_command_nested}",
+					"\\printindex\n\n\\end{document}\n",
+				},
+			},
+			excludes: map[string][]string{
+				"simple.tex": {"--secret", "simple hidden", "\\index{verbose"},
+			},
+			missing: []string{"simple_command.tex", "index.tex"},
+		},
+		{
+			name: "skips document not provided by custom templates",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/latex_other.tmpl": &fstest.MapFile{Data: []byte("\\end{document}")},
+			}).TemplateOptions()},
+			missing: []string{"simple.tex"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerLatex{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerLatex() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerLatex() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerLatex() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+				for _, exclude := range tt.excludes[name] {
+					if strings.Contains(string(b), exclude) {
+						t.Errorf("writerLatex() %s unexpectedly contains %q in:\n%s", name, exclude, string(b))
+					}
+				}
+				assertLatexStructure(t, string(b))
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerLatex() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
+
+// assertLatexStructure checks that environments are properly nested, and that braces outside of verbatim environments
+// are balanced once escaped braces are removed
+func assertLatexStructure(t *testing.T, document string) {
+	t.Helper()
+
+	verbatim := regexp.MustCompile(`(?s)\\begin\{verbatim\}.*?\\end\{verbatim\}`)
+	stripped := verbatim.ReplaceAllString(document, "")
+	stripped = strings.NewReplacer(`\\`, "", `\{`, "", `\}`, "").Replace(stripped)
+	if open, closed := strings.Count(stripped, "{"), strings.Count(stripped, "}"); open != closed {
+		t.Errorf("unbalanced braces: %d opened, %d closed", open, closed)
+	}
+
+	environments := make([]string, 0)
+	for _, match := range regexp.MustCompile(`\\(begin|end)\{([^}]+)\}`).FindAllStringSubmatch(stripped, -1) {
+		if match[1] == "begin" {
+			environments = append(environments, match[2])
+			continue
+		}
+		if len(environments) == 0 || environments[len(environments)-1] != match[2] {
+			t.Fatalf("unexpected \\end{%s} within %v", match[2], environments)
+		}
+		environments = environments[:len(environments)-1]
+	}
+	if len(environments) > 0 {
+		t.Errorf("unclosed environments %v", environments)
+	}
+}
+
+func Test_escapeLatex(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "plain text", input: "plain text", want: "plain text"},
+		{name: "special characters", input: `# $ % & _ { } \`, want: `\# \$ \% \& \_ \{ \} \textbackslash{}`},
+		{name: "text symbols", input: "~ ^ < > |", want: `\textasciitilde{} \textasciicircum{} \textless{} \textgreater{} \textbar{}`},
+		{name: "flags are not typeset as dashes", input: "--flag -f a - b", want: "-{}-flag -f a - b"},
+		{name: "runs of hyphens", input: "---", want: "-{}-{}-"},
+		{name: "unicode", input: "naïve ✓", want: "naïve ✓"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeLatex(tt.input); got != tt.want {
+				t.Errorf("escapeLatex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// mamlFormat is the name of the format which writes PowerShell help in MAML
+const mamlFormat = "Maml"
+
+// mamlTypes are the .NET types of pflag value types, for those which don't map to String
+var mamlTypes = map[string]string{
+	"bool":         "SwitchParameter",
+	"count":        "SwitchParameter",
+	"int":          "Int32",
+	"int8":         "SByte",
+	"int16":        "Int16",
+	"int32":        "Int32",
+	"int64":        "Int64",
+	"uint":         "UInt32",
+	"uint8":        "Byte",
+	"uint16":       "UInt16",
+	"uint32":       "UInt32",
+	"uint64":       "UInt64",
+	"float32":      "Single",
+	"float64":      "Double",
+	"stringSlice":  "String[]",
+	"stringArray":  "String[]",
+	"intSlice":     "Int32[]",
+	"int32Slice":   "Int32[]",
+	"int64Slice":   "Int64[]",
+	"uintSlice":    "UInt32[]",
+	"float32Slice": "Single[]",
+	"float64Slice": "Double[]",
+	"boolSlice":    "Boolean[]",
+}
+
+// mamlParameter is a command:parameter, describing either a flag or a positional argument of a command
+type mamlParameter struct {
+	Name string
+	// Aliases are the parameter's other names, or "none"
+	Aliases  string
+	Required bool
+	// Position is the index of a positional argument, or "named" for flags
+	Position string
+	// VariableLength parameters accept multiple values
+	VariableLength bool
+	// Description is written as maml:para elements
+	Description string
+	Type        string
+	// OptionalValue parameters may be given without a value
+	OptionalValue bool
+	// Values are the allowed values of the parameter
+	Values       []string
+	DefaultValue string
+}
+
+// Switch reports whether the parameter takes no value
+func (p mamlParameter) Switch() bool {
+	return p.Type == "SwitchParameter"
+}
+
+// mamlName converts the name of a flag or argument to a PowerShell parameter name, e.g. dry-run to DryRun
+func mamlName(input string) string {
+	buf := strings.Builder{}
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		buf.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	return buf.String()
+}
+
+type functionsMaml struct {
+}
+
+func (f functionsMaml) FormatHeader(input string) string {
+	return docbookEscaper.Replace(input)
+}
+
+func (f functionsMaml) FormatText(input string) string {
+	return docbookEscaper.Replace(stripansi.String(input))
+}
+
+func (f functionsMaml) FormatOptions(input string) string {
+	return f.paras(trimIndent(input, 2))
+}
+
+// FormatFlag returns the description of the flag as maml:para elements
+func (f functionsMaml) FormatFlag(input Flag) string {
+	_, usage := unquoteUsage(input)
+	if input.Deprecated != "" {
+		usage += fmt.Sprintf(" (DEPRECATED: %s)", input.Deprecated)
+	}
+	return f.paras(usage)
+}
+
+func (f functionsMaml) SeeAlsoPath(input string) string {
+	return f.name(input)
+}
+
+func (f functionsMaml) FormatExample(input string) string {
+	// markdown code fences are removed, as dev:code is preformatted
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return f.FormatText(trimIndent(replaced, -1))
+}
+
+// FormatAutoGenTag returns input for use within an XML comment, which may not contain a double hyphen
+func (f functionsMaml) FormatAutoGenTag(input string) string {
+	for strings.Contains(input, "--") {
+		input = strings.ReplaceAll(input, "--", "- -")
+	}
+	return input
+}
+
+func (f functionsMaml) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides maml-specific template functions
+func (f functionsMaml) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"commands": f.commands,
+		// inc numbers examples from one
+		"inc":        func(i int) int { return i + 1 },
+		"name":       f.name,
+		"noun":       f.noun,
+		"parameters": f.parameters,
+		"paras":      f.paras,
+		"verb":       f.verb,
+	}
+}
+
+// commands flattens the tree of visible commands under c, in depth-first order
+func (f functionsMaml) commands(c Command) []Command {
+	result := make([]Command, 0)
+	for _, section := range sectionCommands(c, []string{"command"}) {
+		result = append(result, section.Command)
+	}
+	return result
+}
+
+// name is the name of the command at input as a PowerShell command, e.g. app-alpha
+func (f functionsMaml) name(input string) string {
+	return f.FormatText(internal.CleanPath(input, "-"))
+}
+
+// verb is the part of the command's name before the first hyphen, as PowerShell splits Verb-Noun names
+func (f functionsMaml) verb(input string) string {
+	verb, _, _ := strings.Cut(f.name(input), "-")
+	return verb
+}
+
+// noun is the part of the command's name after the first hyphen, as PowerShell splits Verb-Noun names
+func (f functionsMaml) noun(input string) string {
+	_, noun, _ := strings.Cut(f.name(input), "-")
+	return noun
+}
+
+// paras splits help text into a maml:para element per paragraph
+func (f functionsMaml) paras(input string) string {
+	paragraphs := make([]string, 0)
+	for _, paragraph := range docbookParagraphs.Split(strings.TrimSpace(input), -1) {
+		if paragraph != "" {
+			paragraphs = append(paragraphs, fmt.Sprintf("<maml:para>%s</maml:para>", f.FormatText(paragraph)))
+		}
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// parameters describes the positional arguments of c, followed by its visible local and inherited flags
+func (f functionsMaml) parameters(c Command) []mamlParameter {
+	result := make([]mamlParameter, 0)
+
+	for i, arg := range c.Args {
+		parameter := mamlParameter{
+			Name:           mamlName(arg.Name),
+			Aliases:        "none",
+			Required:       arg.Required,
+			Position:       strconv.Itoa(i),
+			VariableLength: arg.Variadic,
+			Description:    f.paras(arg.Description),
+			Type:           "String",
+			Values:         arg.Values,
+			DefaultValue:   "None",
+		}
+		if arg.Variadic {
+			parameter.Type = "String[]"
+		}
+		result = append(result, parameter)
+	}
+	if len(c.Args) == 0 && len(c.ValidArgs) > 0 {
+		values := make([]string, 0, len(c.ValidArgs))
+		for _, value := range c.ValidArgs {
+			// valid args may be described, as in "value\tdescription"
+			value, _, _ = strings.Cut(value, "\t")
+			values = append(values, value)
+		}
+		result = append(result, mamlParameter{
+			Name:           "Arguments",
+			Aliases:        "none",
+			Position:       "0",
+			VariableLength: true,
+			Type:           "String[]",
+			Values:         values,
+			DefaultValue:   "None",
+		})
+	}
+
+	for _, flags := range [][]Flag{c.LocalFlags, c.InheritedFlags} {
+		for _, flag := range flags {
+			if flag.Hidden {
+				continue
+			}
+			parameter := mamlParameter{
+				Name:           mamlName(flag.Name),
+				Aliases:        "none",
+				Required:       flag.Required,
+				Position:       "named",
+				VariableLength: strings.HasSuffix(flag.Type, "Slice") || strings.HasSuffix(flag.Type, "Array"),
+				Description:    f.FormatFlag(flag),
+				Type:           "String",
+				OptionalValue:  flag.NoOptDefVal != "",
+				DefaultValue:   "None",
+			}
+			if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+				parameter.Aliases = flag.Shorthand
+			}
+			if t, ok := mamlTypes[flag.Type]; ok {
+				parameter.Type = t
+			}
+			if defaultValue := internal.DefaultValue(flag.Type, flag.DefValue, flag.DefValue); defaultValue != "" {
+				parameter.DefaultValue = defaultValue
+			} else if parameter.Switch() {
+				parameter.DefaultValue = "False"
+			}
+			result = append(result, parameter)
+		}
+	}
+	return result
+}
+
+type writerMaml struct {
+	options TemplateOptions
+}
+
+func (w *writerMaml) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:           mamlFormat,
+		fileExtension:  "xml",
+		out:            out,
+		doc:            doc,
+		options:        w.options,
+		funcs:          functionsMaml{},
+		singleDocument: true,
+		assets: []templateAsset{
+			{target: "help", name: internal.CleanPath(doc.RootCommand.Name) + "-help.xml"},
+		},
+	}
+
+	return helper.write()
+}
+
+func (w *writerMaml) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(mamlFormat, []string{"powershell"}, "xml", func() Writer {
+		return &writerMaml{}
+	})
+}
+
+var (
+	_ functions         = (*functionsMaml)(nil)
+	_ extendedFunctions = (*functionsMaml)(nil)
+)
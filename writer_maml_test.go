@@ -0,0 +1,211 @@
+package venom
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMamlWrite(t *testing.T) {
+	doc := newSimpleTestDocumentation("maml")
+	root := &doc.RootCommand
+	root.Usage = "simple [flags] <name>"
+	root.Short = "s & <s>"
+	root.Long = "simple doc maml.\n\nA second \"paragraph\"."
+	root.Runnable = true
+	root.Args = []Arg{
+		{Name: "name", Description: "the name", Required: true},
+		{Name: "extra-names", Variadic: true, Values: []string{"a", "b"}},
+	}
+	root.LocalFlags = []Flag{
+		{Name: "testing", Shorthand: "t", Usage: "a test flag", DefValue: "false", Type: "bool", Local: true},
+		{Name: "output", Usage: "an <output> flag", DefValue: "json", NoOptDefVal: "yaml", Type: "string", Required: true, Local: true},
+		{Name: "retry-count", Shorthand: "r", ShorthandDeprecated: "use --retry-count", Usage: "retries", DefValue: "3", Type: "int", Local: true},
+		{Name: "tags", Usage: "the `labels` to apply", DefValue: "[]", Type: "stringSlice", Deprecated: "use --labels", Local: true},
+		{Name: "secret", Usage: "a hidden flag", Type: "string", Hidden: true, Local: true},
+	}
+	root.PersistentFlags = nil
+	parent := &ParentCommand{Name: "simple", Short: root.Short, FullPath: "simple"}
+	command := &root.Subcommands[0]
+	command.Aliases = nil
+	command.Runnable = true
+	command.ValidArgs = []string{"x\tthe x", "y"}
+	command.Parent = parent
+	command.Examples = []string{"simple command --testing '<x>'", "simple command y"}
+	command.Deprecated = "use other"
+	command.LocalFlags = nil
+	command.InheritedFlags = []Flag{
+		{Name: "verbose", Shorthand: "v", Usage: "be verbose", Type: "count", DefValue: "0", Inherited: true},
+	}
+	hidden := &root.Subcommands[1]
+	hidden.Short = ""
+	hidden.Deprecated = ""
+	hidden.Args = nil
+	hidden.Parent = parent
+	root.Subcommands = root.Subcommands[:2]
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wants    []string
+		excludes []string
+		missing  bool
+	}{
+		{
+			name: "writes maml help for visible commands",
+			args: args{options: NewOptions().TemplateOptions()},
+			wants: []string{
+				"<!-- generated by: Simple doc maml 1-Jan-2023 -->",
+				`<helpItems schema="maml" xmlns="http://msh">`,
+				"<command:name>simple</command:name>\n      <command:verb>simple</command:verb>\n      <command:noun></command:noun>\n      <maml:description>\n<maml:para>s &amp; &lt;s&gt;</maml:para>",
+				"<maml:description>\n<maml:para>simple doc maml.</maml:para>\n<maml:para>A second &quot;paragraph&quot;.</maml:para>\n    </maml:description>",
+				"<command:syntaxItem>\n        <maml:name>simple</maml:name>",
+				`<command:parameter required="true" variableLength="false" globbing="false" pipelineInput="False" position="0" aliases="none">
+          <maml:name>Name</maml:name>
+          <maml:description>
+<maml:para>the name</maml:para>`,
+				`position="1" aliases="none">
+          <maml:name>ExtraNames</maml:name>`,
+				`<command:parameterValueGroup>
+            <command:parameterValue required="false" variableLength="false">a</command:parameterValue>
+            <command:parameterValue required="false" variableLength="false">b</command:parameterValue>
+          </command:parameterValueGroup>
+          <command:parameterValue required="true" variableLength="true">String[]</command:parameterValue>`,
+				`position="named" aliases="t">
+          <maml:name>Testing</maml:name>
+          <maml:description>
+<maml:para>a test flag</maml:para>
+          </maml:description>
+          <dev:type>
+            <maml:name>SwitchParameter</maml:name>
+            <maml:uri />
+          </dev:type>
+          <dev:defaultValue>False</dev:defaultValue>`,
+				`<command:parameter required="true" variableLength="false" globbing="false" pipelineInput="False" position="named" aliases="none">
+          <maml:name>Output</maml:name>
+          <maml:description>
+<maml:para>an &lt;output&gt; flag</maml:para>
+          </maml:description>
+          <command:parameterValue required="false" variableLength="false">String</command:parameterValue>`,
+				"<dev:defaultValue>json</dev:defaultValue>",
+				`aliases="none">
+          <maml:name>RetryCount</maml:name>`,
+				"<maml:name>Int32</maml:name>",
+				"<dev:defaultValue>3</dev:defaultValue>",
+				"<maml:para>the labels to apply (DEPRECATED: use --labels)</maml:para>",
+				`<command:parameterValue required="true" variableLength="true">String[]</command:parameterValue>`,
+				"<maml:linkText>simple-command</maml:linkText>",
+				"<command:name>simple-command</command:name>\n      <command:verb>simple</command:verb>\n      <command:noun>command</command:noun>",
+				`<command:parameterValue required="false" variableLength="false">x</command:parameterValue>`,
+				`<maml:name>Verbose</maml:name>`,
+				"<maml:alertSet>\n      <maml:alert>\n        <maml:para>Deprecated: use other</maml:para>",
+				"<maml:title>-------------------------- Example 1 --------------------------</maml:title>\n        <dev:code>simple command --testing '&lt;x&gt;'</dev:code>",
+				"<maml:title>-------------------------- Example 2 --------------------------</maml:title>\n        <dev:code>simple command y</dev:code>",
+				"<maml:linkText>simple</maml:linkText>",
+			},
+			excludes: []string{"Secret", "simple-hidden", "the x", `aliases="r"`},
+		},
+		{
+			name: "skips help not provided by custom templates",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/maml_other.tmpl": &fstest.MapFile{Data: []byte(`{{ .RootCommand.Name }}`)},
+			}).TemplateOptions()},
+			missing: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerMaml{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerMaml() error = %v", err)
+			}
+
+			files := out.Files()
+			b, ok := files["simple/simple-help.xml"]
+			if tt.missing {
+				if ok {
+					t.Errorf("writerMaml() unexpectedly wrote simple-help.xml")
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("writerMaml() missing file simple-help.xml in %v", out.Names())
+			}
+			if len(files) != 1 {
+				t.Errorf("writerMaml() wrote %v, want only simple-help.xml", out.Names())
+			}
+
+			for _, want := range tt.wants {
+				if !strings.Contains(string(b), want) {
+					t.Errorf("writerMaml() missing %q in:\n%s", want, string(b))
+				}
+			}
+			for _, exclude := range tt.excludes {
+				if strings.Contains(string(b), exclude) {
+					t.Errorf("writerMaml() unexpectedly contains %q in:\n%s", exclude, string(b))
+				}
+			}
+
+			assertXmlStructure(t, "simple-help.xml", b, mamlNamespaces, mamlContentModels)
+			t.Run("schema", func(t *testing.T) {
+				validateXml(t, files, []string{"simple/simple-help.xml"}, "testdata/maml/Maml.xsd", "--schema")
+			})
+		})
+	}
+}
+
+func Test_mamlName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "output", want: "Output"},
+		{input: "dry-run", want: "DryRun"},
+		{input: "tls.ca_file", want: "TlsCaFile"},
+		{input: "NAME", want: "NAME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := mamlName(tt.input); got != tt.want {
+				t.Errorf("mamlName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// mamlNamespaces are the prefixes conventionally used for the namespaces of PowerShell help
+var mamlNamespaces = map[string]string{
+	"http://msh": "",
+	"http://schemas.microsoft.com/maml/2004/10":             "maml:",
+	"http://schemas.microsoft.com/maml/dev/command/2004/10": "command:",
+	"http://schemas.microsoft.com/maml/dev/2004/10":         "dev:",
+}
+
+// mamlContentModels are the content models of the MAML elements venom emits, in the order read by Get-Help. These are
+// checked whether or not the PSMaml schemas are available for validation.
+var mamlContentModels = map[string]*regexp.Regexp{
+	"helpItems":                   regexp.MustCompile(`^(command:command )+$`),
+	"command:command":             regexp.MustCompile(`^command:details maml:description command:syntax command:parameters command:inputTypes command:returnValues (maml:alertSet )?command:examples command:relatedLinks $`),
+	"command:details":             regexp.MustCompile(`^command:name command:verb command:noun maml:description $`),
+	"command:syntax":              regexp.MustCompile(`^(command:syntaxItem )+$`),
+	"command:syntaxItem":          regexp.MustCompile(`^maml:name (command:parameter )*$`),
+	"command:parameters":          regexp.MustCompile(`^(command:parameter )*$`),
+	"command:parameter":           regexp.MustCompile(`^maml:name maml:description (command:parameterValueGroup )?(command:parameterValue )?dev:type dev:defaultValue $`),
+	"command:parameterValueGroup": regexp.MustCompile(`^(command:parameterValue )+$`),
+	"dev:type":                    regexp.MustCompile(`^maml:name maml:uri $`),
+	"maml:description":            regexp.MustCompile(`^(maml:para )*$`),
+	"maml:alertSet":               regexp.MustCompile(`^(maml:alert )+$`),
+	"maml:alert":                  regexp.MustCompile(`^(maml:para )+$`),
+	"command:examples":            regexp.MustCompile(`^(command:example )*$`),
+	"command:example":             regexp.MustCompile(`^maml:title dev:code dev:remarks $`),
+	"command:relatedLinks":        regexp.MustCompile(`^(maml:navigationLink )*$`),
+	"maml:navigationLink":         regexp.MustCompile(`^maml:linkText maml:uri $`),
+	"maml:para":                   regexp.MustCompile(`^$`),
+}
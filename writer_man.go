@@ -0,0 +1,171 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+)
+
+type functionsMan struct {
+	section string
+}
+
+func (f functionsMan) FormatHeader(input string) string {
+	return strings.ToUpper(f.roff(internal.CleanPath(input, "-")))
+}
+
+func (f functionsMan) FormatText(input string) string {
+	lines := strings.Split(strings.TrimSpace(stripansi.String(input)), "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			// blank lines would otherwise be rendered verbatim; roff expects an explicit paragraph break
+			if len(result) > 0 && result[len(result)-1] != ".PP" {
+				result = append(result, ".PP")
+			}
+			continue
+		}
+		result = append(result, f.roff(line))
+	}
+	return strings.Join(result, "\n")
+}
+
+func (f functionsMan) FormatOptions(input string) string {
+	return fmt.Sprintf(".nf\n%s\n.fi", f.roff(trimIndent(stripansi.String(input), 2)))
+}
+
+func (f functionsMan) FormatFlag(input Flag) string {
+	if input.Hidden {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString(".TP\n")
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString(fmt.Sprintf("\\fB\\-%s\\fP, ", f.roff(input.Shorthand)))
+	}
+	buf.WriteString(fmt.Sprintf("\\fB\\-\\-%s\\fP", f.roff(input.Name)))
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(fmt.Sprintf(" \\fI%s\\fP", f.roff(input.Type)))
+	}
+	if input.Required {
+		buf.WriteString(" (required)")
+	}
+	buf.WriteString("\n")
+	buf.WriteString(f.FormatText(input.Usage))
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			buf.WriteString(fmt.Sprintf(" (default %s)", f.roff(fmt.Sprintf("%q", defaultValue))))
+		} else {
+			buf.WriteString(fmt.Sprintf(" (default %s)", f.roff(defaultValue)))
+		}
+	}
+	if input.Deprecated != "" {
+		buf.WriteString(fmt.Sprintf(" (DEPRECATED: %s)", f.roff(input.Deprecated)))
+	}
+	return buf.String()
+}
+
+func (f functionsMan) SeeAlsoPath(input string) string {
+	return fmt.Sprintf("\\fB%s\\fP(%s)", f.roff(internal.CleanPath(input, "-")), f.section)
+}
+
+func (f functionsMan) FormatExample(input string) string {
+	// code fences used for markdown are meaningless in roff
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return fmt.Sprintf(".PP\n.RS\n.nf\n%s\n.fi\n.RE", f.roff(trimIndent(stripansi.String(replaced), -1)))
+}
+
+func (f functionsMan) FormatAutoGenTag(input string) string {
+	return f.roff(input)
+}
+
+func (f functionsMan) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides man-specific template functions
+func (f functionsMan) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"roff":    f.roff,
+		"section": func() string { return f.section },
+		"name": func(input string) string {
+			return f.roff(internal.CleanPath(input, "-"))
+		},
+		"see_also": f.seeAlso,
+	}
+}
+
+// seeAlso lists references to the parent and all visible subcommands of c, separated by commas
+func (f functionsMan) seeAlso(c Command) string {
+	refs := make([]string, 0)
+	if c.Parent != nil {
+		refs = append(refs, f.SeeAlsoPath(c.Parent.FullPath))
+	}
+	for _, sub := range c.Subcommands {
+		if !sub.Hidden {
+			refs = append(refs, f.SeeAlsoPath(sub.FullPath))
+		}
+	}
+	return strings.Join(refs, ", ")
+}
+
+// roff escapes input so it is printed literally by troff
+func (f functionsMan) roff(input string) string {
+	escaped := strings.ReplaceAll(input, `\`, `\e`)
+	lines := strings.Split(escaped, "\n")
+	for i, line := range lines {
+		// a leading control character would be interpreted as a request
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type writerMan struct {
+	options TemplateOptions
+}
+
+func (w *writerMan) Write(out Output, doc Documentation) error {
+	section := w.options.ManSection
+	if section == "" {
+		section = "1"
+	}
+
+	fns := functionsMan{
+		section: section,
+	}
+
+	helper := writerForTemplates{
+		name:          Man.String(),
+		fileExtension: section,
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         fns,
+		includeIndex:  false,
+		pathSeparator: "-",
+		compress:      w.options.GzipManPages,
+	}
+
+	return helper.write()
+}
+
+func (w *writerMan) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(Man.String(), nil, "1", func() Writer {
+		return &writerMan{}
+	})
+}
+
+var (
+	_ functions         = (*functionsMan)(nil)
+	_ extendedFunctions = (*functionsMan)(nil)
+)
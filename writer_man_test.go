@@ -0,0 +1,170 @@
+package venom
+
+import (
+	"compress/gzip"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc man",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s",
+			Long:     "simple doc man\n\n.with a leading dot and a \\ backslash",
+			Version:  "1.0.0",
+			Args: []Arg{
+				{Name: "NAME", Description: "the name", Required: true},
+				{Name: "FILE", Variadic: true, Values: []string{"a", "b"}},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:        "testing",
+					Shorthand:   "t",
+					Usage:       "a test flag",
+					DefValue:    "false",
+					NoOptDefVal: "true",
+					RawUsage:    "  -t, --testing    a test flag",
+					Type:        "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an output flag",
+					DefValue: "json",
+					RawUsage: "      --output string   an output flag",
+					Type:     "string",
+					Required: true,
+				},
+			},
+			FlagGroups: []FlagGroup{
+				{Kind: FlagGroupMutuallyExclusive, Flags: []string{"testing", "output"}},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Parent:   &ParentCommand{Name: "simple", Short: "s", FullPath: "simple"},
+					Examples: []string{"simple command --testing"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		files    map[string][]string
+		compress bool
+	}{
+		{
+			name: "writes man pages",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.1": {
+					`.TH "SIMPLE" "1" "1-Jan-2023" "simple 1.0.0" ""`,
+					".SH NAME\nsimple \\- s\n",
+					".SH SYNOPSIS\n\\fBsimple [flags]\\fP\n",
+					"simple doc man\n.PP\n\\&.with a leading dot and a \\e backslash\n",
+					".SH ARGUMENTS\n.TP\n\\fBNAME\\fP (required)\nthe name\n.TP\n\\fBFILE...\\fP\nAllowed values: a, b\n",
+					".SH OPTIONS\n.TP\n\\fB\\-t\\fP, \\fB\\-\\-testing\\fP\na test flag\n" +
+						".TP\n\\fB\\-\\-output\\fP \\fIstring\\fP (required)\nan output flag (default \"json\")\n",
+					".SH FLAG CONSTRAINTS\n.IP \\(bu 2\nMutually exclusive: \\fB\\-\\-testing\\fP, \\fB\\-\\-output\\fP\n",
+					".SH SEE ALSO\n.PP\n\\fBsimple-command\\fP(1)\n",
+					".SH HISTORY\n.PP\n1-Jan-2023 generated by: Simple doc man",
+				},
+				"simple-command.1": {
+					`.TH "SIMPLE-COMMAND" "1" "1-Jan-2023" "simple 1.0.0" ""`,
+					".SH NAME\nsimple-command \\- c\n",
+					".SH EXAMPLES\n.PP\n.RS\n.nf\nsimple command --testing\n.fi\n.RE\n",
+					".SH SEE ALSO\n.PP\n\\fBsimple\\fP(1)\n",
+				},
+			},
+		},
+		{
+			name: "writes man pages to custom section with gzip",
+			args: args{options: NewOptions().WithManSection("8").WithGzipManPages().TemplateOptions()},
+			files: map[string][]string{
+				"simple.8.gz": {
+					`.TH "SIMPLE" "8" "1-Jan-2023" "simple 1.0.0" ""`,
+					".SH SEE ALSO\n.PP\n\\fBsimple-command\\fP(8)\n",
+				},
+				"simple-command.8.gz": {
+					`.TH "SIMPLE-COMMAND" "8" "1-Jan-2023" "simple 1.0.0" ""`,
+				},
+			},
+			compress: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			w := writerMan{
+				options: tt.args.options,
+			}
+			if err := w.Write(NewDirectoryOutput(outDir), doc); err != nil {
+				t.Fatalf("writerMan() error = %v", err)
+			}
+
+			for name, wants := range tt.files {
+				f, err := os.Open(filepath.Join(outDir, "simple", name))
+				if err != nil {
+					t.Fatalf("writerMan() unable to open file at expected path %s", name)
+				}
+
+				var r io.Reader = f
+				if tt.compress {
+					gz, err := gzip.NewReader(f)
+					if err != nil {
+						t.Fatalf("writerMan() unable to read gzip file %s: %v", name, err)
+					}
+					r = gz
+				}
+
+				b, err := io.ReadAll(r)
+				_ = f.Close()
+				if err != nil {
+					t.Fatalf("writerMan() unable to read file %s: %v", name, err)
+				}
+
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerMan() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestManWrite_inheritedFlagsUsage(t *testing.T) {
+	root := &cobra.Command{Use: "pinky"}
+	root.PersistentFlags().Bool("verbose", false, "verbose")
+	root.AddCommand(&cobra.Command{Use: "brain", Run: func(cmd *cobra.Command, args []string) {}})
+
+	out := NewMemoryOutput()
+	w := writerMan{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, NewDocumentation(root, NewOptions())); err != nil {
+		t.Fatalf("writerMan() error = %v", err)
+	}
+	b, ok := out.Files()["pinky/pinky-brain.1"]
+	if !ok {
+		t.Fatalf("writerMan() missing file pinky-brain.1 in %v", out.Names())
+	}
+	if want := `\fBpinky brain [flags]\fP`; !strings.Contains(string(b), want) {
+		t.Errorf("writerMan() missing %q in:\n%s", want, string(b))
+	}
+}
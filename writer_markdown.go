@@ -4,12 +4,33 @@ import (
 	"fmt"
 	"github.com/jimschubert/stripansi"
 	"github.com/jimschubert/venom/internal"
+	"path"
+	"path/filepath"
 	"strings"
+	"text/template"
 )
 
+// markdownNavItem is a single entry of the MkDocs and mdBook navigation
+type markdownNavItem struct {
+	// Depth of the command in the command tree, where the root command is 1
+	Depth int
+	Name  string
+	// Path to the command's page, relative to the pages directory
+	Path        string
+	Subcommands bool
+}
+
 type functionsMarkdown struct {
 	stripAnsi      bool
 	maxOptionWidth int
+	// nested lays out pages in a directory per parent command, rather than a single flat directory
+	nested         bool
+	pagesDirectory string
+	// sections are the full paths of commands with subcommands, which are written to _index.md in the Hugo layout
+	sections          map[string]bool
+	root              Command
+	frontMatter       FrontMatterFn
+	frontMatterFormat FrontMatterFormat
 }
 
 func (m functionsMarkdown) FormatOptions(input string) string {
@@ -50,11 +71,15 @@ func (m functionsMarkdown) FormatText(input string) string {
 }
 
 func (m functionsMarkdown) FormatFlag(input Flag) string {
-	return strings.TrimSuffix(input.RawUsage, "\n")
+	usage := strings.TrimSuffix(input.RawUsage, "\n")
+	if usage != "" && input.Required {
+		usage += " (required)"
+	}
+	return usage
 }
 
 func (m functionsMarkdown) SeeAlsoPath(input string) string {
-	return internal.CleanPath(input)
+	return m.pagePath(input)
 }
 
 func (m functionsMarkdown) FormatExample(input string) string {
@@ -72,24 +97,133 @@ func (m functionsMarkdown) IsLocalFlag(input Flag) bool {
 	return !input.Persistent && !input.Inherited
 }
 
+// Funcs provides markdown-specific template functions
+func (m functionsMarkdown) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"front_matter":    m.formatFrontMatter,
+		"link":            m.link,
+		"nav":             m.nav,
+		"pages_directory": func() string { return m.pagesDirectory },
+	}
+}
+
+// formatFrontMatter serializes the front matter of c, or returns an empty string if front matter isn't enabled
+func (m functionsMarkdown) formatFrontMatter(c Command) (string, error) {
+	if m.frontMatter == nil {
+		return "", nil
+	}
+	return frontMatter(m.frontMatterFormat, m.frontMatter, m.root, c)
+}
+
+// pagePath is the page of the command at fullPath, without extension, relative to the pages directory
+func (m functionsMarkdown) pagePath(fullPath string) string {
+	if !m.nested {
+		return internal.CleanPath(fullPath)
+	}
+	segments := strings.Fields(fullPath)
+	for i, segment := range segments {
+		segments[i] = internal.CleanPath(segment)
+	}
+	if m.sections[fullPath] {
+		segments = append(segments, "_index")
+	}
+	return strings.Join(segments, "/")
+}
+
+// link is the relative link from the page of the command at from to the page of the command at to. An empty from
+// refers to the index page.
+func (m functionsMarkdown) link(from string, to string) string {
+	dir := "."
+	if from != "" {
+		dir = path.Dir(m.pagePath(from))
+	}
+	target := m.pagePath(to) + ".md"
+	if rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target)); err == nil {
+		target = filepath.ToSlash(rel)
+	}
+	if strings.HasPrefix(target, "../") {
+		return target
+	}
+	return "./" + target
+}
+
+// nav flattens the tree of visible commands under c, in depth-first order
+func (m functionsMarkdown) nav(c Command) []markdownNavItem {
+	items := make([]markdownNavItem, 0)
+	var walk func(c Command, depth int)
+	walk = func(c Command, depth int) {
+		item := markdownNavItem{Depth: depth, Name: c.Name, Path: m.pagePath(c.FullPath) + ".md"}
+		index := len(items)
+		items = append(items, item)
+		for _, sub := range c.Subcommands {
+			if !sub.Hidden {
+				items[index].Subcommands = true
+				walk(sub, depth+1)
+			}
+		}
+	}
+	walk(c, 1)
+	return items
+}
+
 type writerMarkdown struct {
 	options TemplateOptions
 }
 
-func (w *writerMarkdown) Write(outDir string, doc Documentation) error {
+func (w *writerMarkdown) Write(out Output, doc Documentation) error {
 	fns := functionsMarkdown{
-		stripAnsi:      w.options.StripAnsiInMarkdown,
-		maxOptionWidth: w.options.MaxOptionWidthInMarkdown,
+		stripAnsi:         w.options.StripAnsiInMarkdown,
+		maxOptionWidth:    w.options.MaxOptionWidthInMarkdown,
+		nested:            w.options.NestedMarkdownLayout,
+		root:              doc.RootCommand,
+		frontMatter:       w.options.FrontMatter,
+		frontMatterFormat: w.options.FrontMatterFormat,
+	}
+
+	var indexName string
+	if w.options.HugoLayout {
+		indexName = "_index"
+		fns.sections = make(map[string]bool)
+		var walk func(c Command)
+		walk = func(c Command) {
+			if len(c.Subcommands) > 0 {
+				fns.sections[c.FullPath] = true
+			}
+			for _, sub := range c.Subcommands {
+				walk(sub)
+			}
+		}
+		walk(doc.RootCommand)
+	}
+
+	// both tools require pages in a subdirectory of their configuration; mkdocs may share mdBook's
+	assets := make([]templateAsset, 0)
+	if w.options.MdBook {
+		fns.pagesDirectory = "src"
+		assets = append(assets,
+			templateAsset{target: "summary", name: path.Join(fns.pagesDirectory, "SUMMARY.md")},
+			templateAsset{target: "book", name: "book.toml"},
+		)
+	}
+	if w.options.MkDocs {
+		if fns.pagesDirectory == "" {
+			fns.pagesDirectory = "docs"
+		}
+		assets = append(assets, templateAsset{target: "mkdocs", name: "mkdocs.yml"})
 	}
 
 	helper := writerForTemplates{
-		name:          Markdown.String(),
-		fileExtension: "md",
-		outDir:        outDir,
-		doc:           doc,
-		options:       w.options,
-		funcs:         fns,
-		includeIndex:  true,
+		name:           Markdown.String(),
+		fileExtension:  "md",
+		out:            out,
+		doc:            doc,
+		options:        w.options,
+		funcs:          fns,
+		includeIndex:   true,
+		pagePath:       fns.pagePath,
+		pagesDirectory: fns.pagesDirectory,
+		indexName:      indexName,
+		assets:         assets,
 	}
 
 	return helper.write()
@@ -100,11 +234,12 @@ func (w *writerMarkdown) SetTemplateOptions(options TemplateOptions) {
 }
 
 func init() {
-	registerWriter(Markdown, func() writer {
+	mustRegisterFormat(Markdown.String(), []string{"md"}, "md", func() Writer {
 		return &writerMarkdown{}
 	})
 }
 
 var (
-	_ functions = (*functionsMarkdown)(nil)
+	_ functions         = (*functionsMarkdown)(nil)
+	_ extendedFunctions = (*functionsMarkdown)(nil)
 )
@@ -0,0 +1,197 @@
+package venom
+
+import (
+	"github.com/spf13/cobra"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMarkdownWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc markdown",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Short:    "s",
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Short:    "c",
+					Parent:   &ParentCommand{Name: "simple", Short: "s", FullPath: "simple"},
+					Subcommands: []Command{
+						{
+							Name:     "nested",
+							FullPath: "simple command nested",
+							Short:    "n",
+							Parent:   &ParentCommand{Name: "command", Short: "c", FullPath: "simple command"},
+						},
+					},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name: "writes flat layout",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.md":                {"* [simple command](./simple_command.md) - c\n"},
+				"simple_command.md":        {"* [simple](./simple.md) - s\n* [simple command nested](./simple_command_nested.md) - n\n"},
+				"simple_command_nested.md": {"* [command](./simple_command.md) - c\n"},
+				"index.md":                 {"* [simple](./simple.md) - s\n* [simple command](./simple_command.md) - c\n"},
+			},
+			missing: []string{"mkdocs.yml", "book.toml", "src/SUMMARY.md"},
+		},
+		{
+			name: "writes custom templates with shared partials",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/partials.tmpl":         &fstest.MapFile{Data: []byte(`{{ define "footer" }}shared footer{{ end }}`)},
+				"templates/other_command.tmpl":    &fstest.MapFile{Data: []byte(`{{ other_format_function .Name }}`)},
+				"templates/markdown_command.tmpl": &fstest.MapFile{Data: []byte(`{{ .Name }} {{ template "footer" }}`)},
+			}).TemplateOptions()},
+			files: map[string][]string{
+				"simple.md": {"simple shared footer"},
+			},
+			missing: []string{"index.md"},
+		},
+		{
+			name: "writes nested layout",
+			args: args{options: NewOptions().WithNestedMarkdownLayout().TemplateOptions()},
+			files: map[string][]string{
+				"simple.md":                {"* [simple command](./simple/command.md) - c\n"},
+				"simple/command.md":        {"* [simple](../simple.md) - s\n* [simple command nested](./command/nested.md) - n\n"},
+				"simple/command/nested.md": {"* [command](../command.md) - c\n"},
+				"index.md":                 {"* [simple](./simple.md) - s\n* [simple command](./simple/command.md) - c\n"},
+			},
+		},
+		{
+			name: "writes hugo layout with front matter",
+			args: args{options: NewOptions().WithHugoLayout().WithFrontMatter(nil).TemplateOptions()},
+			files: map[string][]string{
+				"_index.md": {
+					"---\ndescription: s\ntitle: simple\nweight: 1\n---\n\n# simple\n",
+					"* [simple](./simple/_index.md) - s\n* [simple command](./simple/command/_index.md) - c\n",
+				},
+				"simple/_index.md":         {"---\ndescription: s\ntitle: simple\nweight: 1\n---\n\n## simple\n"},
+				"simple/command/_index.md": {"title: simple command\n", "* [simple](../_index.md) - s\n* [simple command nested](./nested.md) - n\n"},
+				"simple/command/nested.md": {"title: simple command nested\n", "* [command](./_index.md) - c\n"},
+			},
+			missing: []string{"index.md", "simple.md"},
+		},
+		{
+			name: "writes mkdocs configuration",
+			args: args{options: NewOptions().WithMkDocs().TemplateOptions()},
+			files: map[string][]string{
+				"docs/simple.md": {"## simple\n"},
+				"docs/index.md":  {"# simple\n"},
+				"mkdocs.yml": {
+					"# generated by: Simple doc markdown 1-Jan-2023\n",
+					"site_name: \"simple\"\ndocs_dir: docs\nnav:\n" +
+						"  - Overview: index.md\n" +
+						"  - \"simple\":\n" +
+						"      - \"simple\": simple.md\n" +
+						"      - \"command\":\n" +
+						"          - \"command\": simple_command.md\n" +
+						"          - \"nested\": simple_command_nested.md\n",
+				},
+			},
+			missing: []string{"simple.md", "src/SUMMARY.md"},
+		},
+		{
+			name: "writes mdbook summary shared with mkdocs",
+			args: args{options: NewOptions().WithMdBook().WithMkDocs().WithNestedMarkdownLayout().TemplateOptions()},
+			files: map[string][]string{
+				"src/simple/command/nested.md": {"## nested\n"},
+				"src/SUMMARY.md": {
+					"# Summary\n\n[Overview](index.md)\n\n" +
+						"- [simple](simple.md)\n" +
+						"    - [command](simple/command.md)\n" +
+						"        - [nested](simple/command/nested.md)\n",
+				},
+				"book.toml":  {"[book]\ntitle = \"simple\"\nsrc = \"src\"\n"},
+				"mkdocs.yml": {"docs_dir: src\nexclude_docs: SUMMARY.md\n"},
+			},
+			missing: []string{"docs/index.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerMarkdown{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerMarkdown() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerMarkdown() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerMarkdown() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerMarkdown() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownWrite_inheritedFlagsUsage(t *testing.T) {
+	root := &cobra.Command{Use: "pinky"}
+	root.PersistentFlags().Bool("verbose", false, "verbose")
+	root.AddCommand(&cobra.Command{Use: "brain", Run: func(cmd *cobra.Command, args []string) {}})
+
+	out := NewMemoryOutput()
+	w := writerMarkdown{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, NewDocumentation(root, NewOptions())); err != nil {
+		t.Fatalf("writerMarkdown() error = %v", err)
+	}
+	b, ok := out.Files()["pinky/pinky_brain.md"]
+	if !ok {
+		t.Fatalf("writerMarkdown() missing file pinky_brain.md in %v", out.Names())
+	}
+	if want := "```\npinky brain [flags]\n```"; !strings.Contains(string(b), want) {
+		t.Errorf("writerMarkdown() missing %q in:\n%s", want, string(b))
+	}
+}
+
+func TestMarkdownWrite_invalidSharedTemplate(t *testing.T) {
+	options := NewOptions().WithCustomTemplates(fstest.MapFS{
+		"templates/partials.tmpl":         &fstest.MapFile{Data: []byte(`{{ define "footer" }}shared footer{{ end `)},
+		"templates/markdown_command.tmpl": &fstest.MapFile{Data: []byte(`{{ .Name }} {{ template "footer" }}`)},
+	}).TemplateOptions()
+
+	w := writerMarkdown{options: options}
+	err := w.Write(NewMemoryOutput(), Documentation{RootCommand: Command{Name: "simple", FullPath: "simple"}})
+	if err == nil || !strings.Contains(err.Error(), "partials.tmpl") {
+		t.Errorf("writerMarkdown() error = %v, want parse error of partials.tmpl", err)
+	}
+}
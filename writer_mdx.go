@@ -0,0 +1,197 @@
+package venom
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+)
+
+// mdxFormat is the name of the format which writes MDX for Docusaurus
+const mdxFormat = "Mdx"
+
+// mdxSidebarItem is an item of a Docusaurus sidebar, see https://docusaurus.io/docs/sidebar/items
+type mdxSidebarItem struct {
+	Type  string           `json:"type"`
+	ID    string           `json:"id,omitempty"`
+	Label string           `json:"label"`
+	Link  *mdxSidebarLink  `json:"link,omitempty"`
+	Items []mdxSidebarItem `json:"items,omitempty"`
+}
+
+type mdxSidebarLink struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// functionsMdx extends the Markdown functions with escaping of characters which MDX would otherwise parse as JSX
+type functionsMdx struct {
+	functionsMarkdown
+	root Command
+}
+
+func (f functionsMdx) FormatText(input string) string {
+	return escapeMdx(f.functionsMarkdown.FormatText(input))
+}
+
+// Funcs provides mdx-specific template functions
+func (f functionsMdx) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"doc_id":           f.docID,
+		"quote":            f.quote,
+		"sidebar":          f.sidebar,
+		"sidebar_position": f.sidebarPosition,
+	}
+}
+
+// docID is the front matter id of the command at input, which is unique within the documentation root
+func (f functionsMdx) docID(input string) string {
+	return internal.CleanPath(input)
+}
+
+// quote returns input as a double-quoted string, valid in both YAML front matter and JavaScript
+func (f functionsMdx) quote(input string) (string, error) {
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(input); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// sidebarPosition is the 1-based position of c among its visible siblings
+func (f functionsMdx) sidebarPosition(c Command) int {
+	return commandPosition(f.root, c)
+}
+
+// sidebar returns the items of a Docusaurus sidebar mirroring the tree of visible commands under c, as indented JSON
+func (f functionsMdx) sidebar(c Command) (string, error) {
+	docRoot := internal.CleanPath(f.root.Name)
+
+	var build func(c Command) mdxSidebarItem
+	build = func(c Command) mdxSidebarItem {
+		id := docRoot + "/" + f.docID(c.FullPath)
+		children := make([]mdxSidebarItem, 0)
+		for _, sub := range c.Subcommands {
+			if !sub.Hidden {
+				children = append(children, build(sub))
+			}
+		}
+		if len(children) == 0 {
+			return mdxSidebarItem{Type: "doc", ID: id, Label: c.Name}
+		}
+		return mdxSidebarItem{
+			Type:  "category",
+			Label: c.Name,
+			Link:  &mdxSidebarLink{Type: "doc", ID: id},
+			Items: children,
+		}
+	}
+
+	b, err := json.MarshalIndent([]mdxSidebarItem{build(c)}, "  ", "  ")
+	return string(b), err
+}
+
+// escapeMdx escapes characters which MDX parses as JSX or expressions, outside of code spans and fenced code blocks
+func escapeMdx(input string) string {
+	lines := strings.Split(input, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		lines[i] = escapeMdxLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeMdxLine(line string) string {
+	buf := strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		char := line[i]
+		switch char {
+		case '`':
+			// copy code spans verbatim, which are closed by a backtick string of the same length
+			run := 1
+			for i+run < len(line) && line[i+run] == '`' {
+				run++
+			}
+			delimiter := line[i : i+run]
+			if end := strings.Index(line[i+run:], delimiter); end >= 0 {
+				buf.WriteString(line[i : i+run+end+run])
+				i += run + end + run - 1
+			} else {
+				buf.WriteString(delimiter)
+				i += run - 1
+			}
+		case '\\':
+			// retain existing escapes
+			buf.WriteByte(char)
+			if i+1 < len(line) {
+				buf.WriteByte(line[i+1])
+				i++
+			}
+		case '{', '}', '<', '>':
+			buf.WriteByte('\\')
+			buf.WriteByte(char)
+		default:
+			buf.WriteByte(char)
+		}
+	}
+	return buf.String()
+}
+
+type writerMdx struct {
+	options TemplateOptions
+}
+
+func (w *writerMdx) Write(out Output, doc Documentation) error {
+	fns := functionsMdx{
+		functionsMarkdown: functionsMarkdown{
+			stripAnsi:      w.options.StripAnsiInMarkdown,
+			maxOptionWidth: w.options.MaxOptionWidthInMarkdown,
+		},
+		root: doc.RootCommand,
+	}
+
+	helper := writerForTemplates{
+		name:          mdxFormat,
+		fileExtension: "mdx",
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         fns,
+		includeIndex:  true,
+		assets: []templateAsset{
+			{target: "sidebars", name: "sidebars.js"},
+			{target: "category", name: "_category_.json"},
+		},
+	}
+
+	return helper.write()
+}
+
+func (w *writerMdx) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(mdxFormat, []string{"docusaurus"}, "mdx", func() Writer {
+		return &writerMdx{}
+	})
+}
+
+var (
+	_ functions         = (*functionsMdx)(nil)
+	_ extendedFunctions = (*functionsMdx)(nil)
+)
@@ -0,0 +1,150 @@
+package venom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMdxWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc mdx",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s <short>",
+			Long:     "simple doc with {braces} and <tags>\n\n```\nsimple <name>\n```",
+			LocalFlags: []Flag{
+				{
+					Name:     "output",
+					Usage:    "an <output> flag",
+					DefValue: "json",
+					RawUsage: "      --output string   an <output> flag (default \"json\")",
+					Type:     "string",
+				},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Parent:   &ParentCommand{Name: "simple", Short: "s <short>", FullPath: "simple"},
+					Subcommands: []Command{
+						{
+							Name:     "nested",
+							FullPath: "simple command nested",
+							Short:    "n",
+							Parent:   &ParentCommand{Name: "command", Short: "c", FullPath: "simple command"},
+						},
+					},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s <short>", FullPath: "simple"},
+				},
+				{
+					Name:     "other",
+					FullPath: "simple other",
+					Short:    "o",
+					Parent:   &ParentCommand{Name: "simple", Short: "s <short>", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	files := map[string][]string{
+		"simple.mdx": {
+			"---\nid: simple\ntitle: \"simple\"\nsidebar_label: \"simple\"\nsidebar_position: 1\ndescription: \"s <short>\"\n---\n",
+			"\ns \\<short\\>\n",
+			"simple doc with \\{braces\\} and \\<tags\\>\n\n```\nsimple <name>\n```\n",
+			"--output string   an <output> flag (default \"json\")\n",
+			"* [simple command](./simple_command.mdx) - c\n* [simple other](./simple_other.mdx) - o\n",
+		},
+		"simple_other.mdx": {
+			"id: simple_other\ntitle: \"simple other\"\nsidebar_label: \"other\"\nsidebar_position: 2\n",
+			"* [simple](./simple.mdx) - s \\<short\\>\n",
+		},
+		"simple_command_nested.mdx": {
+			"id: simple_command_nested\n",
+			"sidebar_position: 1\n",
+		},
+		"index.mdx": {
+			"id: index\ntitle: \"simple\"\nsidebar_label: Overview\nsidebar_position: 0\n",
+		},
+		"sidebars.js": {
+			"// generated by: Simple doc mdx 1-Jan-2023\n",
+			"const sidebars = {\n  \"simple\": [\n    {\n      \"type\": \"category\",\n      \"label\": \"simple\",\n      \"link\": {\n        \"type\": \"doc\",\n        \"id\": \"simple/simple\"\n      },\n",
+			"\"type\": \"doc\",\n              \"id\": \"simple/simple_command_nested\",\n              \"label\": \"nested\"\n",
+			"module.exports = sidebars;\n",
+		},
+		"_category_.json": {
+			"\"label\": \"simple\",\n  \"link\": {\n    \"type\": \"doc\",\n    \"id\": \"index\"\n  }\n",
+		},
+	}
+
+	out := NewMemoryOutput()
+	w := writerMdx{
+		options: NewOptions().TemplateOptions(),
+	}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerMdx() error = %v", err)
+	}
+
+	written := out.Files()
+	for name, wants := range files {
+		b, ok := written["simple/"+name]
+		if !ok {
+			t.Fatalf("writerMdx() missing file %s in %v", name, out.Names())
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(b), want) {
+				t.Errorf("writerMdx() %s missing %q in:\n%s", name, want, string(b))
+			}
+		}
+	}
+
+	if strings.Contains(string(written["simple/sidebars.js"]), "simple_hidden") {
+		t.Errorf("writerMdx() sidebars.js unexpectedly contains hidden command:\n%s", written["simple/sidebars.js"])
+	}
+}
+
+func Test_escapeMdx(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "escapes jsx and expressions",
+			input: "a <b> {c}",
+			want:  `a \<b\> \{c\}`,
+		},
+		{
+			name:  "retains code spans",
+			input: "use `<name>` or ``{`x`}`` with <y>",
+			want:  "use `<name>` or ``{`x`}`` with \\<y\\>",
+		},
+		{
+			name:  "retains fenced code blocks",
+			input: "before <a>\n```shell\necho <b>\n```\n~~~\n{c}\n~~~\nafter {d}",
+			want:  "before \\<a\\>\n```shell\necho <b>\n```\n~~~\n{c}\n~~~\nafter \\{d\\}",
+		},
+		{
+			name:  "retains existing escapes",
+			input: `already \<escaped\> and unclosed ` + "`<code",
+			want:  `already \<escaped\> and unclosed ` + "`\\<code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeMdx(tt.input); got != tt.want {
+				t.Errorf("escapeMdx() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package venom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// nushellFormat is the name of the format which writes a Nushell module of extern definitions
+const nushellFormat = "Nushell"
+
+// nushellInvalidName matches characters which aren't valid in the name of a Nushell parameter
+var nushellInvalidName = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// nushellParameter is a parameter of an extern signature, with the comment which Nushell displays as its description
+type nushellParameter struct {
+	signature   string
+	description string
+}
+
+// nushellType maps the pflag value type of flag to a Nushell type. Values which Nushell doesn't parse as pflag does,
+// such as durations and slices, are passed as strings.
+func nushellType(flag Flag) string {
+	switch flag.Type {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "int"
+	case "float32", "float64":
+		return "float"
+	}
+	return "string"
+}
+
+// nushellString quotes s as a double-quoted Nushell string
+func nushellString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + `"`
+}
+
+// nushellComment writes input as a comment on a single line
+func nushellComment(input string) string {
+	return strings.Join(strings.Fields(input), " ")
+}
+
+// nushellCompleter defines a custom completer named name, which completes values in the form of cobra's valid args
+func nushellCompleter(name string, values []string) string {
+	// values are completed as records when any is described, as in "value\tdescription"
+	described := false
+	for _, value := range values {
+		described = described || strings.Contains(value, "\t")
+	}
+
+	completions := make([]string, 0, len(values))
+	for _, value := range values {
+		value, description, ok := strings.Cut(value, "\t")
+		switch {
+		case ok:
+			completions = append(completions, fmt.Sprintf("{value: %s, description: %s}", nushellString(value), nushellString(description)))
+		case described:
+			completions = append(completions, fmt.Sprintf("{value: %s}", nushellString(value)))
+		default:
+			completions = append(completions, nushellString(value))
+		}
+	}
+	return fmt.Sprintf("def %s [] {\n  [%s]\n}\n\n", nushellString(name), strings.Join(completions, ", "))
+}
+
+func newNushellFlag(flag Flag) nushellParameter {
+	// shorthands remain accepted after deprecation, so are included for the signature to accept them
+	signature := "--" + flag.Name
+	if flag.Shorthand != "" {
+		signature += fmt.Sprintf("(-%s)", flag.Shorthand)
+	}
+	// booleans and counts are switches
+	if flag.Type != "bool" && flag.Type != "count" {
+		signature += ": " + nushellType(flag)
+	}
+
+	_, usage := unquoteUsage(flag)
+	return nushellParameter{signature: signature, description: usage}
+}
+
+func newNushellArg(arg Arg, completer string) nushellParameter {
+	signature := nushellInvalidName.ReplaceAllString(arg.Name, "_")
+	switch {
+	case arg.Variadic:
+		signature = "..." + signature
+	case !arg.Required:
+		signature += "?"
+	}
+	signature += ": string"
+	if completer != "" {
+		signature += "@" + nushellString(completer)
+	}
+	return nushellParameter{signature: signature, description: arg.Description}
+}
+
+// writeNushellExtern writes the extern definition of c, and the completers of its positional arguments
+func writeNushellExtern(buf *strings.Builder, c Command) {
+	parameters := make([]nushellParameter, 0)
+	switch {
+	case len(c.Args) > 0:
+		for _, arg := range c.Args {
+			completer := ""
+			if len(arg.Values) > 0 {
+				completer = fmt.Sprintf("nu-complete %s %s", c.FullPath, arg.Name)
+				buf.WriteString(nushellCompleter(completer, arg.Values))
+			}
+			parameters = append(parameters, newNushellArg(arg, completer))
+		}
+	case len(c.ValidArgs) > 0:
+		completer := "nu-complete " + c.FullPath
+		buf.WriteString(nushellCompleter(completer, c.ValidArgs))
+		parameters = append(parameters, newNushellArg(Arg{Name: "args", Variadic: true}, completer))
+	case c.Runnable:
+		// cobra accepts arbitrary args unless validated by the command's Args, which Nushell would otherwise reject
+		parameters = append(parameters, newNushellArg(Arg{Name: "args", Variadic: true}, ""))
+	}
+
+	// every flag is included, as Nushell rejects flags missing from the signature
+	for _, flags := range [][]Flag{c.LocalFlags, c.InheritedFlags} {
+		for _, flag := range flags {
+			// Nushell adds --help(-h) to every signature
+			if flag.Name != "help" {
+				parameters = append(parameters, newNushellFlag(flag))
+			}
+		}
+	}
+
+	width := 0
+	for _, parameter := range parameters {
+		width = maxInt(width, len(parameter.signature))
+	}
+
+	if c.Short != "" {
+		buf.WriteString(fmt.Sprintf("# %s\n", nushellComment(c.Short)))
+	}
+	buf.WriteString(fmt.Sprintf("export extern %s [\n", nushellString(c.FullPath)))
+	for _, parameter := range parameters {
+		if parameter.description == "" {
+			buf.WriteString(fmt.Sprintf("  %s\n", parameter.signature))
+		} else {
+			buf.WriteString(fmt.Sprintf("  %-*s # %s\n", width, parameter.signature, nushellComment(parameter.description)))
+		}
+	}
+	buf.WriteString("]\n")
+}
+
+type writerNushell struct {
+	options TemplateOptions
+}
+
+func (w *writerNushell) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func (w *writerNushell) Write(out Output, doc Documentation) error {
+	helper := writerForMarshals{
+		name:          nushellFormat,
+		fileExtension: "nu",
+		out:           out,
+		doc:           doc,
+		marshaller:    w.marshal,
+		logger:        w.options.Logger,
+	}
+	return helper.write()
+}
+
+// marshal encodes the documentation as a Nushell module, with an extern definition for every command
+func (w *writerNushell) marshal(v interface{}) ([]byte, error) {
+	doc := v.(*Documentation)
+
+	buf := strings.Builder{}
+	if doc.AutoGenerationTag != "" {
+		buf.WriteString(fmt.Sprintf("# %s %s\n", doc.AutoGenerationTag, doc.GenerationDate))
+	}
+
+	var walk func(c Command)
+	walk = func(c Command) {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		writeNushellExtern(&buf, c)
+		for _, sub := range c.Subcommands {
+			walk(sub)
+		}
+	}
+	walk(doc.RootCommand)
+	return []byte(buf.String()), nil
+}
+
+func init() {
+	mustRegisterFormat(nushellFormat, []string{"nu"}, "nu", func() Writer {
+		return &writerNushell{}
+	})
+}
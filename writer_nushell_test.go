@@ -0,0 +1,97 @@
+package venom
+
+import (
+	"testing"
+)
+
+func TestNushellWrite(t *testing.T) {
+	doc := newSimpleTestDocumentation("nushell")
+	root := &doc.RootCommand
+	root.Runnable = true
+	root.LocalFlags = append(root.LocalFlags, Flag{Name: "help", Shorthand: "h", Usage: "help for simple", Type: "bool", DefValue: "false", Local: true})
+	command := &root.Subcommands[0]
+	command.Runnable = true
+	command.ValidArgs = []string{"x\tthe x", "y"}
+	command.LocalFlags = []Flag{
+		command.LocalFlags[0],
+		{Name: "retries", Usage: "number of retries\nbefore giving up", Type: "int", DefValue: "3", Local: true},
+		command.LocalFlags[1],
+	}
+	hidden := &root.Subcommands[1]
+	hidden.Args = []Arg{hidden.Args[0], {Name: "dry-run", Description: "skip changes"}, hidden.Args[1]}
+
+	want := `# generated by: Simple doc nushell 1-Jan-2023
+
+# s <s>
+export extern "simple" [
+  ...args: string
+  --config(-c): string # the file to read
+  --verbose(-v)        # verbosity
+  --secret             # a hidden flag
+]
+
+def "nu-complete simple command" [] {
+  [{value: "x", description: "the x"}, {value: "y"}]
+}
+
+# c
+export extern "simple command" [
+  ...args: string@"nu-complete simple command"
+  --output(-o): string                         # output format
+  --retries: int                               # number of retries before giving up
+  --tags: string                               # tags
+  --config(-c): string                         # the file to read
+]
+
+def "nu-complete simple hidden rest" [] {
+  ["a", "b"]
+}
+
+# h
+export extern "simple hidden" [
+  first: string                                    # the first
+  dry_run?: string                                 # skip changes
+  ...rest: string@"nu-complete simple hidden rest"
+]
+
+export extern "simple single" [
+  only: string
+]
+`
+
+	out := NewMemoryOutput()
+	w := writerNushell{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerNushell() error = %v", err)
+	}
+
+	got, ok := out.Files()["simple/simple.nu"]
+	if !ok {
+		t.Fatalf("writerNushell() missing file simple.nu in %v", out.Names())
+	}
+	if string(got) != want {
+		t.Errorf("writerNushell() got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_nushellType(t *testing.T) {
+	tests := []struct {
+		flagType string
+		want     string
+	}{
+		{flagType: "string", want: "string"},
+		{flagType: "int", want: "int"},
+		{flagType: "uint16", want: "int"},
+		{flagType: "float64", want: "float"},
+		{flagType: "duration", want: "string"},
+		{flagType: "stringSlice", want: "string"},
+		{flagType: "ip", want: "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flagType, func(t *testing.T) {
+			if got := nushellType(Flag{Type: tt.flagType}); got != tt.want {
+				t.Errorf("nushellType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package venom
 import (
 	"fmt"
 	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
 	"strings"
+	"text/template"
 )
 
 type functionsRest struct {
@@ -21,13 +23,50 @@ func (f functionsRest) FormatOptions(input string) string {
 	return f.indented(input)
 }
 
+// FormatFlag returns an option directive for the flag, which may be cross-referenced with the :option: role
 func (f functionsRest) FormatFlag(input Flag) string {
-	return f.indented(input.Usage)
+	if input.Hidden {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	buf.WriteString(".. option:: ")
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		buf.WriteString(fmt.Sprintf("-%s, ", input.Shorthand))
+	}
+	buf.WriteString("--" + input.Name)
+	if input.Type != "" && input.Type != "bool" {
+		buf.WriteString(" " + input.Type)
+	}
+	buf.WriteString("\n\n")
+
+	description := f.FormatText(input.Usage)
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			defaultValue = fmt.Sprintf("%q", defaultValue)
+		}
+		description += fmt.Sprintf(" (default ``%s``)", defaultValue)
+	}
+	if input.Required {
+		description += " (required)"
+	}
+	if input.Deprecated != "" {
+		description += fmt.Sprintf(" (DEPRECATED: %s)", f.FormatText(input.Deprecated))
+	}
+	for i, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if line != "" {
+			buf.WriteString("   " + line)
+		}
+	}
+	return buf.String()
 }
 
+// SeeAlsoPath returns a cross-reference to the document of the command at input
 func (f functionsRest) SeeAlsoPath(input string) string {
-	ref := strings.ReplaceAll(input, " ", "_")
-	return fmt.Sprintf("`%s <%s.rst>`_", input, ref)
+	return fmt.Sprintf(":doc:`%s <%s>`", input, f.docName(input))
 }
 
 func (f functionsRest) FormatExample(input string) string {
@@ -42,6 +81,28 @@ func (f functionsRest) IsLocalFlag(input Flag) bool {
 	return !input.Persistent && !input.Inherited
 }
 
+// Funcs provides rest-specific template functions
+func (f functionsRest) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"doc_name": f.docName,
+		"commands": f.commands,
+	}
+}
+
+// docName is the Sphinx document name of the command at input, which is its file name without extension
+func (f functionsRest) docName(input string) string {
+	return internal.CleanPath(input)
+}
+
+// commands flattens c and all of its subcommands, in depth-first order
+func (f functionsRest) commands(c Command) []Command {
+	all := []Command{c}
+	for _, sub := range c.Subcommands {
+		all = append(all, f.commands(sub)...)
+	}
+	return all
+}
+
 func (f functionsRest) indented(input string) string {
 	var result []rune
 	addIndent := true
@@ -72,17 +133,23 @@ type writerRest struct {
 	options TemplateOptions
 }
 
-func (w *writerRest) Write(outDir string, doc Documentation) error {
+func (w *writerRest) Write(out Output, doc Documentation) error {
 	fns := functionsRest{}
 
 	helper := writerForTemplates{
 		name:          ReST.String(),
 		fileExtension: "rst",
-		outDir:        outDir,
+		out:           out,
 		doc:           doc,
 		options:       w.options,
 		funcs:         fns,
-		includeIndex:  false,
+		includeIndex:  true,
+	}
+
+	if w.options.SphinxConf {
+		helper.assets = []templateAsset{
+			{target: "conf", name: "conf.py"},
+		}
 	}
 
 	return helper.write()
@@ -93,7 +160,12 @@ func (w *writerRest) SetTemplateOptions(options TemplateOptions) {
 }
 
 func init() {
-	registerWriter(ReST, func() writer {
+	mustRegisterFormat(ReST.String(), []string{"rst"}, "rst", func() Writer {
 		return &writerRest{}
 	})
 }
+
+var (
+	_ functions         = (*functionsRest)(nil)
+	_ extendedFunctions = (*functionsRest)(nil)
+)
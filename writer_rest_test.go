@@ -0,0 +1,127 @@
+package venom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRestWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc rest",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s",
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an output flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+			},
+			FlagGroups: []FlagGroup{
+				{Kind: FlagGroupMutuallyExclusive, Flags: []string{"testing", "output"}},
+			},
+			Runnable: true,
+			Subcommands: []Command{
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					Parent:   &ParentCommand{Name: "simple", Short: "s", FullPath: "simple"},
+					InheritedFlags: []Flag{
+						{Name: "config", Usage: "config file\nwith a second line", Type: "string", Inherited: true},
+					},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		files   map[string][]string
+		missing []string
+	}{
+		{
+			name: "writes sphinx-ready pages",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.rst": {
+					".. _simple:\n\nsimple\n------\n",
+					".. program:: simple\n\nOptions\n~~~~~~~\n",
+					".. option:: -t, --testing\n\n   a test flag\n\n",
+					".. option:: --output string\n\n   an output flag (default ``\"json\"``) (required)\n",
+					"* Mutually exclusive: :option:`--testing`, :option:`--output`\n",
+					"* :doc:`simple command <simple_command>` - c\n",
+				},
+				"simple_command.rst": {
+					".. _simple_command:\n\ncommand\n-------\n",
+					".. program:: simple command\n\nOptions inherited from parent commands\n",
+					".. option:: --config string\n\n   config file\n   with a second line\n",
+					"* :doc:`simple <simple>`  - s\n",
+				},
+				"index.rst": {
+					"simple\n======\n\ns\n",
+					".. toctree::\n   :maxdepth: 1\n   :caption: Commands\n\n   simple <simple>\n   simple command <simple_command>\n",
+				},
+			},
+			missing: []string{"conf.py"},
+		},
+		{
+			name: "writes sphinx configuration",
+			args: args{options: NewOptions().WithSphinxConf().TemplateOptions()},
+			files: map[string][]string{
+				"conf.py": {
+					"# generated by: Simple doc rest 1-Jan-2023\n",
+					"project = \"simple\"\nroot_doc = \"index\"\n",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerRest{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerRest() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerRest() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerRest() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerRest() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
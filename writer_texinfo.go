@@ -0,0 +1,159 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/stripansi"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+)
+
+// texinfoFormat is the name of the format which writes a Texinfo manual
+const texinfoFormat = "Texinfo"
+
+// texinfoSectioning are the sectioning commands by depth in the command tree; deeper commands reuse the last
+var texinfoSectioning = []string{"@chapter", "@section", "@subsection", "@subsubsection"}
+
+var texinfoEscaper = strings.NewReplacer("@", "@@", "{", "@{", "}", "@}")
+
+// texinfoNodeEscaper replaces characters which are not allowed in node names
+var texinfoNodeEscaper = strings.NewReplacer(",", "", ":", "", ".", "", "(", "", ")", "")
+
+type functionsTexinfo struct {
+}
+
+func (f functionsTexinfo) FormatHeader(input string) string {
+	return texinfoEscaper.Replace(input)
+}
+
+func (f functionsTexinfo) FormatText(input string) string {
+	return texinfoEscaper.Replace(stripansi.String(input))
+}
+
+func (f functionsTexinfo) FormatOptions(input string) string {
+	return fmt.Sprintf("@example\n%s\n@end example", f.FormatText(trimIndent(input, 2)))
+}
+
+// FormatFlag returns a two-column table entry for the flag. Flags are added to the option index only where they are
+// declared, rather than on every command inheriting them.
+func (f functionsTexinfo) FormatFlag(input Flag) string {
+	if input.Hidden {
+		return ""
+	}
+
+	value := ""
+	if input.Type != "" && input.Type != "bool" {
+		value = fmt.Sprintf(" @var{%s}", f.FormatText(input.Type))
+	}
+
+	names := make([]string, 0, 2)
+	if input.Shorthand != "" && input.ShorthandDeprecated == "" {
+		names = append(names, "-"+input.Shorthand)
+	}
+	names = append(names, "--"+input.Name)
+
+	buf := strings.Builder{}
+	for i, name := range names {
+		if i == 0 {
+			buf.WriteString("@item ")
+		} else {
+			buf.WriteString("@itemx ")
+		}
+		buf.WriteString(f.FormatText(name) + value + "\n")
+	}
+	if !input.Inherited {
+		for _, name := range names {
+			buf.WriteString("@opindex " + f.FormatText(name) + "\n")
+		}
+	}
+
+	buf.WriteString(f.FormatText(input.Usage))
+	if defaultValue := internal.DefaultValue(input.Type, input.DefValue, input.DefValue); defaultValue != "" {
+		if input.Type == "string" {
+			defaultValue = fmt.Sprintf("%q", defaultValue)
+		}
+		buf.WriteString(fmt.Sprintf(" (default @code{%s})", f.FormatText(defaultValue)))
+	}
+	if input.Required {
+		buf.WriteString(" (required)")
+	}
+	if input.Deprecated != "" {
+		buf.WriteString(fmt.Sprintf(" (DEPRECATED: %s)", f.FormatText(input.Deprecated)))
+	}
+	return buf.String()
+}
+
+// SeeAlsoPath returns a cross reference to the node of the command at input
+func (f functionsTexinfo) SeeAlsoPath(input string) string {
+	return fmt.Sprintf("@ref{%s}", f.node(input))
+}
+
+func (f functionsTexinfo) FormatExample(input string) string {
+	// markdown code fences are replaced by an example environment
+	replaced := strings.TrimPrefix(strings.TrimSuffix(input, "\n```"), "```\n")
+	replaced = strings.TrimPrefix(strings.TrimSuffix(replaced, "```"), "```")
+	return fmt.Sprintf("@example\n%s\n@end example", f.FormatText(trimIndent(replaced, -1)))
+}
+
+func (f functionsTexinfo) FormatAutoGenTag(input string) string {
+	return f.FormatText(input)
+}
+
+func (f functionsTexinfo) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides texinfo-specific template functions
+func (f functionsTexinfo) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"node":  f.node,
+		"nodes": f.nodes,
+	}
+}
+
+// node is the name of the node documenting the command at input
+func (f functionsTexinfo) node(input string) string {
+	return texinfoEscaper.Replace(texinfoNodeEscaper.Replace(input))
+}
+
+// nodes flattens the tree of visible commands under c in the order of their menus, which makeinfo requires to match
+// the order of nodes in the manual
+func (f functionsTexinfo) nodes(c Command) []sectionedCommand {
+	return sectionCommands(c, texinfoSectioning)
+}
+
+type writerTexinfo struct {
+	options TemplateOptions
+}
+
+func (w *writerTexinfo) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:           texinfoFormat,
+		fileExtension:  "texi",
+		out:            out,
+		doc:            doc,
+		options:        w.options,
+		funcs:          functionsTexinfo{},
+		singleDocument: true,
+		assets: []templateAsset{
+			{target: "manual", name: internal.CleanPath(doc.RootCommand.Name) + ".texi"},
+		},
+	}
+
+	return helper.write()
+}
+
+func (w *writerTexinfo) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(texinfoFormat, []string{"texi"}, "texi", func() Writer {
+		return &writerTexinfo{}
+	})
+}
+
+var (
+	_ functions         = (*functionsTexinfo)(nil)
+	_ extendedFunctions = (*functionsTexinfo)(nil)
+)
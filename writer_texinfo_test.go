@@ -0,0 +1,225 @@
+package venom
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTexinfoWrite(t *testing.T) {
+	doc := Documentation{
+		GenerationDate:    "1-Jan-2023",
+		AutoGenerationTag: "generated by: Simple doc texinfo",
+		RootCommand: Command{
+			Name:     "simple",
+			FullPath: "simple",
+			Usage:    "simple [flags]",
+			Short:    "s {s}",
+			Long:     "simple doc texinfo, user@example.com",
+			Args: []Arg{
+				{Name: "NAME", Description: "the name", Required: true, Variadic: true},
+			},
+			LocalFlags: []Flag{
+				{
+					Name:      "testing",
+					Shorthand: "t",
+					Usage:     "a test flag",
+					DefValue:  "false",
+					Type:      "bool",
+				},
+				{
+					Name:     "output",
+					Usage:    "an output flag",
+					DefValue: "json",
+					Type:     "string",
+					Required: true,
+				},
+				{
+					Name:   "secret",
+					Usage:  "a hidden flag",
+					Type:   "string",
+					Hidden: true,
+				},
+			},
+			FlagGroups: []FlagGroup{
+				{Kind: FlagGroupMutuallyExclusive, Flags: []string{"testing", "output"}},
+			},
+			Runnable: true,
+			Groups:   []CommandGroup{{ID: "main", Title: "Main Commands:"}},
+			Subcommands: []Command{
+				{
+					Name:     "other",
+					FullPath: "simple other",
+					Short:    "o",
+					Parent:   &ParentCommand{Name: "simple", Short: "s {s}", FullPath: "simple"},
+				},
+				{
+					Name:     "command",
+					FullPath: "simple command",
+					Usage:    "simple command",
+					Short:    "c",
+					GroupID:  "main",
+					Runnable: true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s {s}", FullPath: "simple"},
+					Examples: []string{"simple command --testing '{x}'"},
+					InheritedFlags: []Flag{
+						{Name: "verbose", Usage: "be verbose", Type: "bool", Inherited: true},
+					},
+					Subcommands: []Command{
+						{
+							Name:     "nested",
+							FullPath: "simple command nested",
+							Short:    "n",
+							Parent:   &ParentCommand{Name: "command", Short: "c", FullPath: "simple command"},
+						},
+					},
+				},
+				{
+					Name:     "hidden",
+					FullPath: "simple hidden",
+					Hidden:   true,
+					Parent:   &ParentCommand{Name: "simple", Short: "s {s}", FullPath: "simple"},
+				},
+			},
+		},
+	}
+
+	type args struct {
+		options TemplateOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		files    map[string][]string
+		excludes map[string][]string
+		missing  []string
+	}{
+		{
+			name: "writes texinfo manual",
+			args: args{options: NewOptions().TemplateOptions()},
+			files: map[string][]string{
+				"simple.texi": {
+					"\\input texinfo\n",
+					"@setfilename simple.info\n",
+					"* simple: (simple). s @{s@}\n",
+					"@node Top\n@top simple\n",
+					"@menu\n* simple:: s @{s@}\n* Option Index::\n@end menu",
+					"@node simple\n@chapter simple\n\ns @{s@}\n\nsimple doc texinfo, user@@example.com",
+					"@example\nsimple [flags]\n@end example",
+					"@table @code\n@item NAME...\nthe name (required)\n@end table",
+					"@table @option\n@item -t\n@itemx --testing\n@opindex -t\n@opindex --testing\na test flag\n",
+					"@item --output @var{string}\n@opindex --output\nan output flag (default @code{\"json\"}) (required)\n@end table",
+					"Mutually exclusive: @option{--testing}, @option{--output}",
+					"@menu\n\nMain Commands\n* simple command:: c\n\nAdditional Commands\n* simple other:: o\n@end menu",
+					"@node simple command\n@section simple command",
+					"@example\nsimple command --testing '@{x@}'\n@end example",
+					"@item --verbose\nbe verbose\n",
+					"@node simple command nested\n@subsection simple command nested",
+					"@node simple other\n@section simple other",
+					"@printindex op\n\n@bye\n",
+					"@emph{generated by: Simple doc texinfo 1-Jan-2023}",
+				},
+			},
+			excludes: map[string][]string{
+				"simple.texi": {"--secret", "simple hidden", "@opindex --verbose"},
+			},
+			missing: []string{"simple_command.texi", "index.texi"},
+		},
+		{
+			name: "skips manual not provided by custom templates",
+			args: args{options: NewOptions().WithCustomTemplates(fstest.MapFS{
+				"templates/texinfo_other.tmpl": &fstest.MapFile{Data: []byte("@bye")},
+			}).TemplateOptions()},
+			missing: []string{"simple.texi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewMemoryOutput()
+			w := writerTexinfo{
+				options: tt.args.options,
+			}
+			if err := w.Write(out, doc); err != nil {
+				t.Fatalf("writerTexinfo() error = %v", err)
+			}
+
+			files := out.Files()
+			for name, wants := range tt.files {
+				b, ok := files["simple/"+name]
+				if !ok {
+					t.Fatalf("writerTexinfo() missing file %s in %v", name, out.Names())
+				}
+				for _, want := range wants {
+					if !strings.Contains(string(b), want) {
+						t.Errorf("writerTexinfo() %s missing %q in:\n%s", name, want, string(b))
+					}
+				}
+				for _, exclude := range tt.excludes[name] {
+					if strings.Contains(string(b), exclude) {
+						t.Errorf("writerTexinfo() %s unexpectedly contains %q in:\n%s", name, exclude, string(b))
+					}
+				}
+				assertTexinfoStructure(t, string(b))
+			}
+
+			for _, name := range tt.missing {
+				if _, ok := files["simple/"+name]; ok {
+					t.Errorf("writerTexinfo() unexpectedly wrote %s", name)
+				}
+			}
+		})
+	}
+}
+
+// assertTexinfoStructure checks the rules makeinfo enforces when computing node pointers from menus: every node other
+// than Top is listed in exactly one menu, and nodes appear in the order of a depth-first walk of the menus.
+func assertTexinfoStructure(t *testing.T, manual string) {
+	t.Helper()
+
+	if open, closed := strings.Count(manual, "{")-strings.Count(manual, "@{"), strings.Count(manual, "}")-strings.Count(manual, "@}"); open != closed {
+		t.Errorf("unbalanced braces: %d opened, %d closed", open, closed)
+	}
+
+	nodePattern := regexp.MustCompile(`(?m)^@node (.+)$`)
+	menuPattern := regexp.MustCompile(`(?s)@menu\n(.*?)@end menu`)
+	entryPattern := regexp.MustCompile(`(?m)^\* (.+?)::`)
+
+	nodes := make([]string, 0)
+	menus := make(map[string][]string)
+	parts := nodePattern.FindAllStringSubmatchIndex(manual, -1)
+	for i, part := range parts {
+		name := manual[part[2]:part[3]]
+		nodes = append(nodes, name)
+		end := len(manual)
+		if i+1 < len(parts) {
+			end = parts[i+1][0]
+		}
+		for _, menu := range menuPattern.FindAllStringSubmatch(manual[part[1]:end], -1) {
+			for _, entry := range entryPattern.FindAllStringSubmatch(menu[1], -1) {
+				menus[name] = append(menus[name], entry[1])
+			}
+		}
+	}
+
+	walked := make([]string, 0)
+	listed := make(map[string]int)
+	var walk func(name string)
+	walk = func(name string) {
+		walked = append(walked, name)
+		for _, child := range menus[name] {
+			listed[child]++
+			walk(child)
+		}
+	}
+	walk("Top")
+
+	if strings.Join(walked, "|") != strings.Join(nodes, "|") {
+		t.Errorf("node order %q does not match menu order %q", nodes, walked)
+	}
+	for _, name := range nodes[1:] {
+		if listed[name] != 1 {
+			t.Errorf("node %q is listed in %d menus", name, listed[name])
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/venom/internal"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// textFormat is the name of the format which writes plain text help, as output by cobra
+const textFormat = "Text"
+
+// minTextPadding is cobra's minimum padding of command names and paths in usage
+const minTextPadding = 11
+
+// textCommand adapts a Command to the methods called by cobra's default help and usage templates, so that the Text
+// format may use those templates verbatim. Padding of subcommand names is computed from the subcommands known to venom,
+// and differs from cobra only where a hidden command has the longest name and hidden commands aren't documented.
+type textCommand struct {
+	Command
+	parent *textCommand
+}
+
+func (c textCommand) UseLine() string {
+	return c.Usage
+}
+
+func (c textCommand) CommandPath() string {
+	return c.FullPath
+}
+
+func (c textCommand) NameAndAliases() string {
+	return strings.Join(append([]string{c.Name}, c.Aliases...), ", ")
+}
+
+func (c textCommand) HasExample() bool {
+	return len(c.Examples) > 0
+}
+
+func (c textCommand) Example() string {
+	return strings.Join(c.Examples, "\n")
+}
+
+func (c textCommand) Commands() []textCommand {
+	result := make([]textCommand, 0, len(c.Subcommands))
+	for _, sub := range c.Subcommands {
+		result = append(result, textCommand{Command: sub, parent: &c})
+	}
+	return result
+}
+
+func (c textCommand) HasSubCommands() bool {
+	return len(c.Subcommands) > 0
+}
+
+func (c textCommand) HasAvailableSubCommands() bool {
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c textCommand) IsAvailableCommand() bool {
+	if c.Deprecated != "" || c.Hidden || c.isHelpCommand() {
+		return false
+	}
+	return c.Runnable || c.HasAvailableSubCommands()
+}
+
+func (c textCommand) IsAdditionalHelpTopicCommand() bool {
+	if c.Runnable || c.Deprecated != "" || c.Hidden {
+		return false
+	}
+	for _, sub := range c.Commands() {
+		if !sub.IsAdditionalHelpTopicCommand() {
+			return false
+		}
+	}
+	return true
+}
+
+func (c textCommand) HasHelpSubCommands() bool {
+	for _, sub := range c.Commands() {
+		if sub.IsAdditionalHelpTopicCommand() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c textCommand) AllChildCommandsHaveGroup() bool {
+	for _, sub := range c.Commands() {
+		if (sub.IsAvailableCommand() || sub.isHelpCommand()) && sub.GroupID == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (c textCommand) NamePadding() int {
+	if c.parent == nil {
+		return minTextPadding
+	}
+	padding := minTextPadding
+	for _, sibling := range c.parent.Subcommands {
+		padding = maxInt(padding, len(sibling.Name))
+	}
+	return padding
+}
+
+func (c textCommand) CommandPathPadding() int {
+	if c.parent == nil {
+		return minTextPadding
+	}
+	padding := minTextPadding
+	for _, sibling := range c.parent.Subcommands {
+		padding = maxInt(padding, len(sibling.FullPath))
+	}
+	return padding
+}
+
+func (c textCommand) LocalFlags() textFlags {
+	return c.Command.LocalFlags
+}
+
+func (c textCommand) InheritedFlags() textFlags {
+	return c.Command.InheritedFlags
+}
+
+func (c textCommand) HasAvailableLocalFlags() bool {
+	return c.LocalFlags().hasAvailableFlags()
+}
+
+func (c textCommand) HasAvailableInheritedFlags() bool {
+	return c.InheritedFlags().hasAvailableFlags()
+}
+
+// isHelpCommand reports whether c is cobra's default help command, which is only available in help topics
+func (c textCommand) isHelpCommand() bool {
+	return c.parent != nil && c.Name == "help"
+}
+
+// textFlags adapts flags to the pflag.FlagSet methods called by cobra's default usage template
+type textFlags []Flag
+
+// FlagUsages formats flags as pflag.FlagSet.FlagUsages, aligning usages to the longest flag in this set
+func (f textFlags) FlagUsages() string {
+	lines := make([]string, 0, len(f))
+	maxLength := 0
+	for _, flag := range f {
+		if flag.Hidden {
+			continue
+		}
+
+		line := ""
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			line = fmt.Sprintf("  -%s, --%s", flag.Shorthand, flag.Name)
+		} else {
+			line = fmt.Sprintf("      --%s", flag.Name)
+		}
+
+		varName, usage := unquoteUsage(flag)
+		if varName != "" {
+			line += " " + varName
+		}
+		if flag.NoOptDefVal != "" {
+			switch flag.Type {
+			case "string":
+				line += fmt.Sprintf("[=\"%s\"]", flag.NoOptDefVal)
+			case "bool":
+				if flag.NoOptDefVal != "true" {
+					line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
+				}
+			case "count":
+				if flag.NoOptDefVal != "+1" {
+					line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
+				}
+			default:
+				line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
+			}
+		}
+
+		// the separator is replaced by spacing once all flags are measured
+		line += "\x00"
+		maxLength = maxInt(maxLength, len(line))
+
+		line += usage
+		if internal.DefaultValue(flag.Type, flag.DefValue, flag.DefValue) != "" {
+			if flag.Type == "string" {
+				line += fmt.Sprintf(" (default %q)", flag.DefValue)
+			} else {
+				line += fmt.Sprintf(" (default %s)", flag.DefValue)
+			}
+		}
+		if flag.Deprecated != "" {
+			line += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
+		}
+		lines = append(lines, line)
+	}
+
+	buf := strings.Builder{}
+	for _, line := range lines {
+		separator := strings.Index(line, "\x00")
+		spacing := strings.Repeat(" ", maxLength-separator)
+		usage := strings.ReplaceAll(line[separator+1:], "\n", "\n"+strings.Repeat(" ", maxLength+2))
+		buf.WriteString(fmt.Sprintf("%s %s %s\n", line[:separator], spacing, usage))
+	}
+	return buf.String()
+}
+
+func (f textFlags) hasAvailableFlags() bool {
+	for _, flag := range f {
+		if !flag.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// unquoteUsage extracts a back-quoted name from the usage of flag, falling back to its type, as pflag.UnquoteUsage
+func unquoteUsage(flag Flag) (string, string) {
+	usage := flag.Usage
+	if start := strings.Index(usage, "`"); start >= 0 {
+		if end := strings.Index(usage[start+1:], "`"); end >= 0 {
+			name := usage[start+1 : start+1+end]
+			return name, usage[:start] + name + usage[start+1+end+1:]
+		}
+	}
+
+	switch flag.Type {
+	case "bool":
+		return "", usage
+	case "float64":
+		return "float", usage
+	case "int64":
+		return "int", usage
+	case "uint64":
+		return "uint", usage
+	case "stringSlice":
+		return "strings", usage
+	case "intSlice":
+		return "ints", usage
+	case "uintSlice":
+		return "uints", usage
+	case "boolSlice":
+		return "bools", usage
+	}
+	return flag.Type, usage
+}
+
+type functionsText struct {
+}
+
+func (f functionsText) FormatHeader(input string) string {
+	return input
+}
+
+func (f functionsText) FormatText(input string) string {
+	return input
+}
+
+func (f functionsText) FormatOptions(input string) string {
+	return input
+}
+
+func (f functionsText) FormatFlag(input Flag) string {
+	return textFlags{input}.FlagUsages()
+}
+
+func (f functionsText) SeeAlsoPath(input string) string {
+	return input
+}
+
+func (f functionsText) FormatExample(input string) string {
+	return input
+}
+
+func (f functionsText) FormatAutoGenTag(input string) string {
+	return input
+}
+
+func (f functionsText) IsLocalFlag(input Flag) bool {
+	return !input.Persistent && !input.Inherited
+}
+
+// Funcs provides the functions of cobra's default templates, along with text-specific template functions
+func (f functionsText) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"cobra":    f.cobra,
+		"commands": f.commands,
+		"rpad": func(s string, padding int) string {
+			return fmt.Sprintf(fmt.Sprintf("%%-%ds", padding), s)
+		},
+		"trimTrailingWhitespaces": func(s string) string {
+			return strings.TrimRightFunc(s, unicode.IsSpace)
+		},
+	}
+}
+
+// cobra adapts c to the methods called by cobra's default templates
+func (f functionsText) cobra(c Command) textCommand {
+	return textCommand{Command: c}
+}
+
+// commands flattens the tree of commands under c in depth-first order, adapted to cobra's default templates
+func (f functionsText) commands(c Command) []textCommand {
+	result := make([]textCommand, 0)
+	var walk func(c textCommand)
+	walk = func(c textCommand) {
+		result = append(result, c)
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(f.cobra(c))
+	return result
+}
+
+type writerText struct {
+	options TemplateOptions
+}
+
+func (w *writerText) Write(out Output, doc Documentation) error {
+	helper := writerForTemplates{
+		name:          textFormat,
+		fileExtension: "txt",
+		out:           out,
+		doc:           doc,
+		options:       w.options,
+		funcs:         functionsText{},
+	}
+
+	if w.options.ConcatenatedText {
+		helper.singleDocument = true
+		helper.assets = []templateAsset{
+			{target: "all", name: internal.CleanPath(doc.RootCommand.Name) + ".txt"},
+		}
+	}
+
+	return helper.write()
+}
+
+func (w *writerText) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func init() {
+	mustRegisterFormat(textFormat, []string{"txt"}, "txt", func() Writer {
+		return &writerText{}
+	})
+}
+
+var (
+	_ functions         = (*functionsText)(nil)
+	_ extendedFunctions = (*functionsText)(nil)
+)
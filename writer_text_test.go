@@ -0,0 +1,151 @@
+package venom
+
+import (
+	"bytes"
+	"github.com/spf13/cobra"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTextTestCommand() *cobra.Command {
+	run := func(cmd *cobra.Command, args []string) {}
+
+	root := &cobra.Command{
+		Use:     "app",
+		Short:   "app does things",
+		Long:    "app does things.\n\nIn great detail.  \n",
+		Version: "1.0.0",
+		Run:     run,
+	}
+	root.PersistentFlags().StringP("config", "c", "", "path to the `file` to read")
+	root.PersistentFlags().CountP("verbose", "v", "increase verbosity")
+	root.Flags().Duration("timeout", 30*time.Second, "how long to wait")
+	root.Flags().String("format", "", "output format")
+	root.Flags().Lookup("format").NoOptDefVal = "json"
+	root.Flags().StringSlice("tags", []string{"a", "b"}, "tags to apply")
+	root.Flags().Bool("legacy", false, "an old flag")
+	_ = root.Flags().MarkDeprecated("legacy", "use --format")
+	root.Flags().IntP("retries", "r", 3, "number of retries\nbefore giving up")
+	_ = root.Flags().MarkShorthandDeprecated("retries", "use --retries")
+	root.Flags().Float64("ratio", 0.5, "a ratio")
+	root.AddGroup(&cobra.Group{ID: "main", Title: "Main Commands:"})
+
+	alpha := &cobra.Command{
+		Use:     "alpha [name]",
+		Aliases: []string{"a", "al"},
+		Short:   "the first command",
+		Example: "  app alpha x\n  app alpha y --force",
+		GroupID: "main",
+		Run:     run,
+	}
+	alpha.Flags().Bool("force", false, "force it")
+	alpha.Flags().StringP("output", "o", "text", "output `mode`")
+	_ = alpha.MarkFlagRequired("output")
+
+	nested := &cobra.Command{Use: "nested-with-a-long-name", Short: "nested", Run: run}
+	alpha.AddCommand(nested, &cobra.Command{Use: "b", Short: "short name", Run: run})
+
+	beta := &cobra.Command{Use: "beta", Short: "the second command", Run: run}
+	deprecated := &cobra.Command{Use: "old", Short: "an old command", Deprecated: "use beta", Run: run}
+	hidden := &cobra.Command{Use: "secret", Short: "a hidden command", Hidden: true, Run: run}
+	topic := &cobra.Command{Use: "topic", Short: "a help topic", Long: "Only text."}
+	parent := &cobra.Command{Use: "parent", Short: "has only subcommands", GroupID: "main"}
+	parent.AddCommand(&cobra.Command{Use: "child", Short: "a child", Run: run})
+
+	root.AddCommand(alpha, beta, deprecated, hidden, topic, parent)
+	root.InitDefaultHelpCmd()
+	root.InitDefaultHelpFlag()
+	return root
+}
+
+func TestTextWrite_parity(t *testing.T) {
+	root := newTextTestCommand()
+	doc := NewDocumentation(root, NewOptions())
+
+	out := NewMemoryOutput()
+	w := writerText{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerText() error = %v", err)
+	}
+	files := out.Files()
+
+	concatenated := NewMemoryOutput()
+	w = writerText{options: NewOptions().WithConcatenatedText().TemplateOptions()}
+	if err := w.Write(concatenated, doc); err != nil {
+		t.Fatalf("writerText() error = %v", err)
+	}
+	all, ok := concatenated.Files()["app/app.txt"]
+	if !ok {
+		t.Fatalf("writerText() missing concatenated file in %v", concatenated.Names())
+	}
+	if len(concatenated.Files()) != 1 {
+		t.Errorf("writerText() wrote %v, want only the concatenated file", concatenated.Names())
+	}
+
+	checked := 0
+	var check func(cmd *cobra.Command)
+	check = func(cmd *cobra.Command) {
+		if cmd.Hidden {
+			if _, ok := files["app/"+strings.ReplaceAll(cmd.CommandPath(), " ", "_")+".txt"]; ok {
+				t.Errorf("writerText() unexpectedly wrote hidden command %s", cmd.CommandPath())
+			}
+			return
+		}
+
+		help := bytes.Buffer{}
+		cmd.SetOut(&help)
+		if err := cmd.Help(); err != nil {
+			t.Fatalf("%s: Help() error = %v", cmd.CommandPath(), err)
+		}
+
+		name := "app/" + strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".txt"
+		got, ok := files[name]
+		if !ok {
+			t.Fatalf("writerText() missing file %s in %v", name, out.Names())
+		}
+		if string(got) != help.String() {
+			t.Errorf("%s: got\n%s\nwant help\n%s", name, got, help.String())
+		}
+		if cmd.Runnable() || cmd.HasSubCommands() {
+			if usage := cmd.UsageString(); !strings.HasSuffix(string(got), usage) {
+				t.Errorf("%s: got\n%s\nwant usage\n%s", name, got, usage)
+			}
+		}
+		if section := "$ " + cmd.CommandPath() + " --help\n" + help.String(); !strings.Contains(string(all), section) {
+			t.Errorf("concatenated file missing %q in:\n%s", section, all)
+		}
+
+		checked++
+		for _, sub := range cmd.Commands() {
+			check(sub)
+		}
+	}
+	check(root)
+
+	if checked != 10 {
+		t.Errorf("checked %d commands, want 10", checked)
+	}
+}
+
+func Test_textFlags_FlagUsages(t *testing.T) {
+	root := newTextTestCommand()
+	doc := NewDocumentation(root, NewOptions())
+
+	tests := []struct {
+		name  string
+		flags []Flag
+		want  string
+	}{
+		{name: "local flags", flags: doc.RootCommand.LocalFlags, want: root.LocalFlags().FlagUsages()},
+		{name: "inherited flags", flags: doc.RootCommand.Subcommands[0].InheritedFlags, want: root.Commands()[0].InheritedFlags().FlagUsages()},
+		{name: "no flags", flags: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textFlags(tt.flags).FlagUsages(); got != tt.want {
+				t.Errorf("FlagUsages() got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package venom
+
+import (
+	"fmt"
+	"github.com/jimschubert/venom/internal"
+	"path"
+	"strings"
+)
+
+// usageSpecFormat is the name of the format which writes a usage spec in KDL
+const usageSpecFormat = "UsageSpec"
+
+// usageSpecFileName is the conventional name of a usage spec
+const usageSpecFileName = "usage.kdl"
+
+type kdlProperties []internal.KdlProperty
+
+// with appends the property when value isn't its zero value
+func (p kdlProperties) with(key string, value interface{}) kdlProperties {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return p
+		}
+	case bool:
+		if !v {
+			return p
+		}
+	}
+	return append(p, internal.KdlProperty{Key: key, Value: value})
+}
+
+// newUsageSpec describes the root command as the top-level nodes of a usage spec, see https://usage.jdx.dev/spec/
+func newUsageSpec(doc Documentation) []internal.KdlNode {
+	root := doc.RootCommand
+	nodes := []internal.KdlNode{
+		{Name: "name", Arguments: []interface{}{root.Name}},
+		{Name: "bin", Arguments: []interface{}{root.Name}},
+	}
+	if root.Version != "" {
+		nodes = append(nodes, internal.KdlNode{Name: "version", Arguments: []interface{}{root.Version}})
+	}
+	if root.Short != "" {
+		nodes = append(nodes, internal.KdlNode{Name: "about", Arguments: []interface{}{root.Short}})
+	}
+	if root.Long != "" {
+		nodes = append(nodes, internal.KdlNode{Name: "long_about", Arguments: []interface{}{root.Long}})
+	}
+	return append(nodes, newUsageCommandChildren(root)...)
+}
+
+// newUsageCommandChildren describes the flags, args, and subcommands of c
+func newUsageCommandChildren(c Command) []internal.KdlNode {
+	nodes := make([]internal.KdlNode, 0)
+
+	// inherited flags are omitted, as they're global flags of an ancestor
+	persistent := make(map[string]bool)
+	for _, flag := range c.PersistentFlags {
+		persistent[flag.Name] = true
+	}
+	for _, flag := range c.LocalFlags {
+		nodes = append(nodes, newUsageFlag(flag, persistent[flag.Name]))
+	}
+
+	for _, arg := range c.Args {
+		nodes = append(nodes, newUsageArg(arg))
+	}
+	if len(c.Args) == 0 && len(c.ValidArgs) > 0 {
+		nodes = append(nodes, newUsageArg(Arg{Name: "arg", Values: c.ValidArgs}))
+	}
+
+	for _, sub := range c.Subcommands {
+		nodes = append(nodes, newUsageCommand(sub))
+	}
+	return nodes
+}
+
+func newUsageCommand(c Command) internal.KdlNode {
+	children := make([]internal.KdlNode, 0)
+	if len(c.Aliases) > 0 {
+		aliases := make([]interface{}, 0, len(c.Aliases))
+		for _, alias := range c.Aliases {
+			aliases = append(aliases, alias)
+		}
+		children = append(children, internal.KdlNode{Name: "alias", Arguments: aliases})
+	}
+	children = append(children, newUsageCommandChildren(c)...)
+
+	return internal.KdlNode{
+		Name:      "cmd",
+		Arguments: []interface{}{c.Name},
+		Properties: kdlProperties{}.
+			with("help", c.Short).
+			with("long_help", c.Long).
+			with("hide", c.Hidden).
+			with("deprecated", c.Deprecated).
+			with("subcommand_required", !c.Runnable && len(c.Subcommands) > 0),
+		Children: children,
+	}
+}
+
+func newUsageFlag(flag Flag, global bool) internal.KdlNode {
+	names := make([]string, 0, 3)
+	if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+		names = append(names, "-"+flag.Shorthand)
+	}
+	names = append(names, "--"+flag.Name)
+
+	valueName, usage := unquoteUsage(flag)
+	if flag.Type != "bool" && flag.Type != "count" && valueName != "" {
+		names = append(names, fmt.Sprintf("<%s>", valueName))
+	}
+
+	var defaultValue interface{}
+	if value := internal.DefaultValue(flag.Type, flag.DefValue, flag.DefValue); value != "" {
+		defaultValue = value
+		if flag.Type == "bool" {
+			defaultValue = value == "true"
+		}
+	}
+
+	properties := kdlProperties{}.
+		with("help", usage).
+		with("required", flag.Required).
+		with("global", global).
+		with("count", flag.Type == "count").
+		// slices accumulate values across occurrences of the flag
+		with("var", strings.HasSuffix(flag.Type, "Slice") || strings.HasSuffix(flag.Type, "Array")).
+		with("hide", flag.Hidden).
+		with("deprecated", flag.Deprecated)
+	if defaultValue != nil {
+		properties = properties.with("default", defaultValue)
+	}
+
+	return internal.KdlNode{
+		Name:       "flag",
+		Arguments:  []interface{}{strings.Join(names, " ")},
+		Properties: properties,
+	}
+}
+
+func newUsageArg(arg Arg) internal.KdlNode {
+	name := fmt.Sprintf("[%s]", arg.Name)
+	if arg.Required {
+		name = fmt.Sprintf("<%s>", arg.Name)
+	}
+	if arg.Variadic {
+		name += "..."
+	}
+
+	node := internal.KdlNode{
+		Name:       "arg",
+		Arguments:  []interface{}{name},
+		Properties: kdlProperties{}.with("help", arg.Description).with("var", arg.Variadic),
+	}
+	if len(arg.Values) > 0 {
+		choices := make([]interface{}, 0, len(arg.Values))
+		for _, value := range arg.Values {
+			choices = append(choices, value)
+		}
+		node.Children = []internal.KdlNode{{Name: "choices", Arguments: choices}}
+	}
+	return node
+}
+
+type writerUsage struct {
+	options TemplateOptions
+}
+
+func (w *writerUsage) SetTemplateOptions(options TemplateOptions) {
+	w.options = options
+}
+
+func (w *writerUsage) Write(out Output, doc Documentation) error {
+	data, err := internal.MarshalKdl(newUsageSpec(doc))
+	if err != nil {
+		return err
+	}
+	if doc.AutoGenerationTag != "" {
+		data = append([]byte(fmt.Sprintf("// %s %s\n", doc.AutoGenerationTag, doc.GenerationDate)), data...)
+	}
+
+	name := path.Join(internal.CleanPath(doc.RootCommand.Name), usageSpecFileName)
+	err = out.WriteFile(name, data)
+	if err == nil {
+		w.options.Logger.Printf("[%s] Wrote file %s", usageSpecFormat, name)
+	}
+	return err
+}
+
+func init() {
+	mustRegisterFormat(usageSpecFormat, []string{"usage", "kdl"}, "kdl", func() Writer {
+		return &writerUsage{}
+	})
+}
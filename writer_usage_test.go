@@ -0,0 +1,83 @@
+package venom
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUsageSpecWrite(t *testing.T) {
+	doc := newSimpleTestDocumentation("usage")
+	root := &doc.RootCommand
+	root.Short = "s"
+	root.Long = "simple doc \"usage\"\n\nwith paragraphs"
+	root.Version = "1.0.0"
+	root.Runnable = true
+	root.LocalFlags = []Flag{
+		root.LocalFlags[0],
+		root.LocalFlags[1],
+		{Name: "color", Usage: "colorize output", Type: "bool", DefValue: "true", Local: true},
+		root.LocalFlags[2],
+	}
+	command := &root.Subcommands[0]
+	command.Aliases = []string{"cmd", "co"}
+	command.Runnable = true
+	command.LocalFlags[0].NoOptDefVal = ""
+	old := root.Subcommands[1]
+	old.Name = "old"
+	old.FullPath = "simple group old"
+	old.Short = ""
+	old.Runnable = true
+	root.Subcommands = []Command{
+		root.Subcommands[0],
+		{Name: "group", FullPath: "simple group", Short: "g", Subcommands: []Command{old}},
+	}
+
+	out := NewMemoryOutput()
+	w := writerUsage{options: NewOptions().TemplateOptions()}
+	if err := w.Write(out, doc); err != nil {
+		t.Fatalf("writerUsage() error = %v", err)
+	}
+	got, ok := out.Files()["simple/usage.kdl"]
+	if !ok {
+		t.Fatalf("writerUsage() missing file usage.kdl in %v", out.Names())
+	}
+	if !strings.HasPrefix(string(got), "// generated by: Simple doc usage 1-Jan-2023\n") {
+		t.Errorf("writerUsage() missing generation comment in:\n%s", got)
+	}
+
+	want, err := os.ReadFile("testdata/simple.usage.kdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("writerUsage() got\n%s\nwant\n%s", got, want)
+	}
+
+	// the usage CLI parses the written spec with its KDL 2.0 parser
+	t.Run("usage cli", func(t *testing.T) {
+		usage := requireTool(t, "usage")
+		path := filepath.Join(t.TempDir(), "usage.kdl")
+		if err := os.WriteFile(path, got, DefaultFileMode); err != nil {
+			t.Fatal(err)
+		}
+		output, err := exec.Command(usage, "generate", "json", "--file", path).Output()
+		if err != nil {
+			t.Fatalf("usage generate json error = %v", err)
+		}
+
+		spec := struct {
+			Bin     string `json:"bin"`
+			Version string `json:"version"`
+		}{}
+		if err := json.Unmarshal(output, &spec); err != nil {
+			t.Fatalf("usage generate json wrote invalid JSON: %v\n%s", err, output)
+		}
+		if spec.Bin != "simple" || spec.Version != "1.0.0" {
+			t.Errorf("usage read bin %q version %q, want simple 1.0.0 from:\n%s", spec.Bin, spec.Version, output)
+		}
+	})
+}
@@ -8,11 +8,11 @@ func (w *writerYaml) SetTemplateOptions(options TemplateOptions) {
 	w.options = options
 }
 
-func (w *writerYaml) Write(outDir string, doc Documentation) error {
+func (w *writerYaml) Write(out Output, doc Documentation) error {
 	helper := writerForMarshals{
 		name:          Yaml.String(),
 		fileExtension: "yml",
-		outDir:        outDir,
+		out:           out,
 		doc:           doc,
 		marshaller:    w.options.YamlMarshaler,
 		logger:        w.options.Logger,
@@ -21,7 +21,7 @@ func (w *writerYaml) Write(outDir string, doc Documentation) error {
 }
 
 func init() {
-	registerWriter(Yaml, func() writer {
+	mustRegisterFormat(Yaml.String(), []string{"yml"}, "yml", func() Writer {
 		return &writerYaml{}
 	})
 }
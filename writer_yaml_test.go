@@ -62,7 +62,7 @@ func TestYamlWrite(t *testing.T) {
 			w := writerYaml{
 				options: tt.args.options,
 			}
-			if err := w.Write(outDir, tt.args.doc); (err != nil) != tt.wantErr {
+			if err := w.Write(NewDirectoryOutput(outDir), tt.args.doc); (err != nil) != tt.wantErr {
 				t.Fatalf("writerYaml() error = %v, wantErr %v", err, tt.wantErr)
 			}
 